@@ -0,0 +1,154 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package audit implements the log of the administrative operations on the engine's data.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+)
+
+// Suffix is appended to the database file path to get the default path of the audit log.
+const Suffix = ".audit.jsonl"
+
+// File operations.
+const (
+	FileMoved   = "moved"
+	FileDeleted = "deleted"
+)
+
+// Entry is the record of an administrative operation on a library.
+type Entry struct {
+	Time      time.Time
+	User      string
+	Host      string
+	Command   string
+	Arguments []string // Command line arguments of the engine.
+	Library   string
+	Changes   []*Change        `json:",omitempty"`
+	Files     []*FileOperation `json:",omitempty"`
+}
+
+// Change is the modification of an object of the database. For removals, After is nil and Before is the whole object.
+type Change struct {
+	Object string // "library" or "release VERSION".
+	Field  string `json:",omitempty"` // Empty if the whole object changed.
+	Before interface{}
+	After  interface{}
+}
+
+// FileOperation is an operation on a file of the engine's data.
+type FileOperation struct {
+	Operation string
+	Path      string
+	NewPath   string `json:",omitempty"`
+}
+
+// Path returns the path of the audit log of the given configuration.
+func Path(config *configuration.Config) string {
+	if config.AuditLogFile != "" {
+		return config.AuditLogFile
+	}
+	return config.LibrariesDB + Suffix
+}
+
+// NewEntry returns an entry for an operation of the given command on the given library, by the current user.
+func NewEntry(command string, libraryName string) *Entry {
+	entry := Entry{
+		Time:      time.Now().UTC(),
+		Command:   command,
+		Arguments: os.Args[1:],
+		Library:   libraryName,
+	}
+	if currentUser, err := user.Current(); err == nil {
+		entry.User = currentUser.Username
+	} else {
+		entry.User = os.Getenv("USER")
+	}
+	entry.Host, _ = os.Hostname()
+	return &entry
+}
+
+// AddChange records the change of the field of an object.
+func (entry *Entry) AddChange(object string, field string, before interface{}, after interface{}) {
+	entry.Changes = append(entry.Changes, &Change{Object: object, Field: field, Before: before, After: after})
+}
+
+// AddFileOperation records an operation on a file. newPath is only used by FileMoved operations.
+func (entry *Entry) AddFileOperation(operation string, path string, newPath string) {
+	entry.Files = append(entry.Files, &FileOperation{Operation: operation, Path: path, NewPath: newPath})
+}
+
+// Append durably adds the entries to the audit log at the given path.
+func Append(path string, entries ...*Entry) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// History returns the entries of the audit log at the given path concerning the given library, in chronological order.
+func History(path string, libraryName string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024) // Entries of removals contain whole releases.
+	for line := 1; scanner.Scan(); line++ {
+		entry := new(Entry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("invalid audit log entry at line %d: %w", line, err)
+		}
+		if entry.Library == libraryName {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,78 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, "/data/db.json"+Suffix, Path(&configuration.Config{LibrariesDB: "/data/db.json"}))
+	assert.Equal(t, "/data/audit.jsonl", Path(&configuration.Config{LibrariesDB: "/data/db.json", AuditLogFile: "/data/audit.jsonl"}))
+}
+
+func TestHistory(t *testing.T) {
+	logFolder, err := paths.MkTempDir("", "audit-TestHistory")
+	require.NoError(t, err)
+	defer logFolder.RemoveAll()
+	logPath := logFolder.Join("db.json" + Suffix)
+
+	_, err = History(logPath.String(), "FooLib")
+	assert.Error(t, err, "Nonexistent log")
+
+	fooEntry := NewEntry("modify", "FooLib")
+	fooEntry.AddChange("library", "Repository", "https://github.com/Bar/FooLib.git", "https://github.com/Baz/FooLib.git")
+	fooEntry.AddFileOperation(FileMoved, "/libraries/github.com/Bar/FooLib-1.0.0.zip", "/libraries/github.com/Baz/FooLib-1.0.0.zip")
+	require.NoError(t, Append(logPath.String(), fooEntry))
+	bazEntry := NewEntry("remove", "BazLib")
+	bazEntry.AddChange("release 1.0.0", "", map[string]string{"LibraryName": "BazLib"}, nil)
+	fooRemovalEntry := NewEntry("remove", "FooLib")
+	fooRemovalEntry.AddFileOperation(FileDeleted, "/libraries/github.com/Baz/FooLib-1.0.0.zip", "")
+	require.NoError(t, Append(logPath.String(), bazEntry, fooRemovalEntry))
+
+	entries, err := History(logPath.String(), "FooLib")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "modify", entries[0].Command)
+	assert.NotEmpty(t, entries[0].User)
+	require.Len(t, entries[0].Changes, 1)
+	assert.Equal(t, "https://github.com/Bar/FooLib.git", entries[0].Changes[0].Before)
+	assert.Equal(t, "https://github.com/Baz/FooLib.git", entries[0].Changes[0].After)
+	assert.Equal(t, FileMoved, entries[0].Files[0].Operation)
+	assert.Equal(t, "remove", entries[1].Command)
+	assert.Equal(t, FileDeleted, entries[1].Files[0].Operation)
+
+	entries, err = History(logPath.String(), "QuxLib")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	require.NoError(t, logPath.WriteFile([]byte("{\n")))
+	_, err = History(logPath.String(), "FooLib")
+	assert.Error(t, err, "Invalid entry")
+}
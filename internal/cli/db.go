@@ -0,0 +1,54 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cli
+
+import (
+	"github.com/arduino/libraries-repository-engine/internal/command/migrate"
+	"github.com/spf13/cobra"
+)
+
+// dbCmd defines the `db` CLI subcommand.
+var dbCmd = &cobra.Command{
+	Short: "Manage the libraries database",
+	Long:  "Manage the libraries database storage",
+	Use:   "db",
+}
+
+// dbMigrateCmd defines the `db migrate` CLI subcommand.
+var dbMigrateCmd = &cobra.Command{
+	Short:                 "Migrate the database to another storage backend",
+	Long:                  "Copy the content of the libraries database to a new database using another storage backend",
+	DisableFlagsInUseLine: true,
+	Use: `migrate FLAG... BACKEND PATH
+
+Copy the content of the database configured by LibrariesDB and LibrariesDBBackend to a new database at PATH, stored with
+backend BACKEND ("json" or "bolt").`,
+	Args: cobra.ExactArgs(2),
+	Run:  migrate.Run,
+}
+
+func init() {
+	dbCmd.AddCommand(dbMigrateCmd)
+	rootCmd.AddCommand(dbCmd)
+}
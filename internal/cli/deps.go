@@ -0,0 +1,95 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cli
+
+import (
+	"github.com/arduino/libraries-repository-engine/internal/command/deps"
+	"github.com/spf13/cobra"
+)
+
+// depsCmd defines the `deps` CLI subcommand.
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Library dependencies",
+	Long:  "Inspect the dependency graph of the indexed library releases",
+}
+
+// depsShowCmd defines the `deps show` CLI subcommand.
+var depsShowCmd = &cobra.Command{
+	Short:                 "Show library dependencies",
+	Long:                  "Show the dependencies and dependents of a library",
+	DisableFlagsInUseLine: true,
+	Use: `show [FLAG]... LIBRARY_NAME
+
+Show the libraries library name LIBRARY_NAME depends on and the libraries depending on it.`,
+	Args: cobra.ExactArgs(1),
+	Run:  deps.Show,
+}
+
+// depsCyclesCmd defines the `deps cycles` CLI subcommand.
+var depsCyclesCmd = &cobra.Command{
+	Short:                 "Detect dependency cycles",
+	Long:                  "List the groups of libraries which depend on each other",
+	DisableFlagsInUseLine: true,
+	Use: `cycles [FLAG]...
+
+List the groups of libraries which depend on each other, directly or indirectly.`,
+	Args: cobra.NoArgs,
+	Run:  deps.Cycles,
+}
+
+// depsBrokenCmd defines the `deps broken` CLI subcommand.
+var depsBrokenCmd = &cobra.Command{
+	Short:                 "List broken dependencies",
+	Long:                  "List the dependencies on missing or retired libraries",
+	DisableFlagsInUseLine: true,
+	Use: `broken [FLAG]...
+
+List the libraries depending on a library which is missing from the database or retired.`,
+	Args: cobra.NoArgs,
+	Run:  deps.Broken,
+}
+
+// depsExportCmd defines the `deps export` CLI subcommand.
+var depsExportCmd = &cobra.Command{
+	Short:                 "Export dependency graph",
+	Long:                  "Export the dependency graph in DOT or JSON format",
+	DisableFlagsInUseLine: true,
+	Use: `export [FLAG]...
+
+Print the dependency graph of the indexed library releases in Graphviz DOT or JSON format.`,
+	Args: cobra.NoArgs,
+	Run:  deps.Export,
+}
+
+func init() {
+	for _, command := range []*cobra.Command{depsShowCmd, depsCyclesCmd, depsBrokenCmd} {
+		command.Flags().String("format", "text", "Output format: text or json")
+		depsCmd.AddCommand(command)
+	}
+	depsExportCmd.Flags().String("format", "dot", "Output format: dot or json")
+	depsCmd.AddCommand(depsExportCmd)
+
+	rootCmd.AddCommand(depsCmd)
+}
@@ -0,0 +1,77 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cli
+
+import (
+	"github.com/arduino/libraries-repository-engine/internal/command/query"
+	"github.com/spf13/cobra"
+)
+
+// listCmd defines the `list` CLI subcommand.
+var listCmd = &cobra.Command{
+	Short:                 "List libraries",
+	Long:                  "List the libraries of the database",
+	DisableFlagsInUseLine: true,
+	Use: `list [FLAG]...
+
+List the libraries of the database, with the data of their latest release.`,
+	Args: cobra.NoArgs,
+	Run:  query.List,
+}
+
+// searchCmd defines the `search` CLI subcommand.
+var searchCmd = &cobra.Command{
+	Short:                 "Search libraries",
+	Long:                  "Search the libraries of the database",
+	DisableFlagsInUseLine: true,
+	Use: `search [FLAG]... QUERY
+
+List the libraries of the database whose name, sentence, paragraph, author or maintainer contains QUERY.`,
+	Args: cobra.ExactArgs(1),
+	Run:  query.Search,
+}
+
+// showCmd defines the `show` CLI subcommand.
+var showCmd = &cobra.Command{
+	Short:                 "Show library details",
+	Long:                  "Show the details of a library or library release",
+	DisableFlagsInUseLine: true,
+	Use: `show [FLAG]... LIBRARY_NAME[@RELEASE]
+
+Show the data of library name LIBRARY_NAME and the list of its releases.
+-or-
+Show the data of release RELEASE of library name LIBRARY_NAME.`,
+	Args: cobra.ExactArgs(1),
+	Run:  query.Show,
+}
+
+func init() {
+	for _, command := range []*cobra.Command{listCmd, searchCmd} {
+		query.AddFilterFlags(command)
+	}
+	for _, command := range []*cobra.Command{listCmd, searchCmd, showCmd} {
+		query.AddFormatFlag(command)
+		rootCmd.AddCommand(command)
+	}
+}
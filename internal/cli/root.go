@@ -43,6 +43,7 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().String("config-file", "config.json", "Configuration file path")
+	rootCmd.PersistentFlags().Duration("lock-timeout", 0, "Time to wait for other commands to release the data lock (e.g. 10m)")
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -40,10 +40,21 @@ For each of the library registrations in the file at REGISTRY_FILE_PATH:
 - check their repository for tags not already in the database
 - check whether the new tag meets the requirements for addition to the index
 - add library release to the database and store archive for the compliant tag
-- generate the Library Manager index file`,
+- generate the Library Manager index file
+
+If --library flags are provided, only the registrations of those libraries are processed.
+
+If the --dry-run flag is provided, the checks are done but no archives, database changes, logs or index are written.`,
 	Run: sync.Run,
 }
 
 func init() {
+	syncCmd.Flags().StringArray("library", nil, "Only sync the library of this name (repeatable)")
+	syncCmd.Flags().StringArray("tag", nil, "Only sync this tag of the library specified via --library (repeatable)")
+	syncCmd.Flags().String("report", "", "Write a JSON report of the sync outcome to this file")
+	syncCmd.Flags().Bool("recheck", false, "Check again the tags that were previously rejected and the repositories unchanged since the last sync")
+	syncCmd.Flags().Bool("dry-run", false, "Check new releases without making any changes to the Library Manager content")
+	syncCmd.Flags().String("previous", "", "Log the changes of the generated library index compared to this previously published index")
+
 	rootCmd.AddCommand(syncCmd)
 }
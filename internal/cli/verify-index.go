@@ -0,0 +1,47 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cli
+
+import (
+	verifyindex "github.com/arduino/libraries-repository-engine/internal/command/verify-index"
+	"github.com/spf13/cobra"
+)
+
+// verifyIndexCmd defines the `verify-index` CLI subcommand.
+var verifyIndexCmd = &cobra.Command{
+	Short:                 "Verify library index signature",
+	Long:                  "Verify the signature of a library index",
+	DisableFlagsInUseLine: true,
+	Use: `verify-index INDEX_FILE PUBLIC_KEY_FILE [SIGNATURE_FILE]
+
+Check that the detached signature of the library index INDEX_FILE was made with the private key matching
+PUBLIC_KEY_FILE (an OpenPGP public key, or an Ed25519 public key in PEM format). SIGNATURE_FILE defaults to INDEX_FILE
+with the .sig suffix.`,
+	Args: cobra.RangeArgs(2, 3),
+	Run:  verifyindex.Run,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyIndexCmd)
+}
@@ -0,0 +1,49 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cli
+
+import (
+	"github.com/arduino/libraries-repository-engine/internal/command/verify"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd defines the `verify` CLI subcommand.
+var verifyCmd = &cobra.Command{
+	Short:                 "Verify release archives",
+	Long:                  "Verify the release archives against the database",
+	DisableFlagsInUseLine: true,
+	Use: `verify [FLAG]...
+
+Check that the archive of every release in the database exists, matches the stored size and checksum, has the expected
+structure, and that the release URL matches the download server configuration.`,
+	Args: cobra.NoArgs,
+	Run:  verify.Run,
+}
+
+func init() {
+	verifyCmd.Flags().String("format", "text", "Output format: text or json")
+	verifyCmd.Flags().Bool("fail", false, "Exit with a non-zero status if problems are found")
+
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,179 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package deps implements the `deps` CLI subcommands used to inspect the dependency graph of the indexed library
+// releases.
+package deps
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/arduino/libraries-repository-engine/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// libraryDependencies is the output of the `deps show` command.
+type libraryDependencies struct {
+	Name         string  `json:"name"`
+	Dependencies []*edge `json:"dependencies"`
+	Dependents   []*edge `json:"dependents"`
+}
+
+// graphExport is the JSON format of the graph.
+type graphExport struct {
+	Libraries    []*node `json:"libraries"`
+	Dependencies []*edge `json:"dependencies"`
+}
+
+// Show executes the `deps show` command.
+func Show(command *cobra.Command, cliArguments []string) {
+	format := output.GetFormat(command.Flags(), output.TextFormat, output.JSONFormat)
+	dependencyGraph := loadGraph(command)
+
+	libraryNode, found := dependencyGraph.libraries[cliArguments[0]]
+	if !found {
+		feedback.Errorf("Library of name %s has no indexed releases and no dependents", cliArguments[0])
+		os.Exit(1)
+	}
+	result := libraryDependencies{
+		Name:         libraryNode.Name,
+		Dependencies: sortedEdges(libraryNode.dependencies),
+		Dependents:   sortedEdges(libraryNode.dependents),
+	}
+
+	if format == output.JSONFormat {
+		output.PrintJSON(result)
+		return
+	}
+	printShow(os.Stdout, dependencyGraph, &result)
+}
+
+// Cycles executes the `deps cycles` command.
+func Cycles(command *cobra.Command, cliArguments []string) {
+	format := output.GetFormat(command.Flags(), output.TextFormat, output.JSONFormat)
+	cycles := loadGraph(command).cycles()
+
+	if format == output.JSONFormat {
+		output.PrintJSON(cycles)
+		return
+	}
+	for _, cycle := range cycles {
+		fmt.Println(strings.Join(cycle, ", "))
+	}
+	fmt.Printf("%d dependency cycles\n", len(cycles))
+}
+
+// Broken executes the `deps broken` command.
+func Broken(command *cobra.Command, cliArguments []string) {
+	format := output.GetFormat(command.Flags(), output.TextFormat, output.JSONFormat)
+	dependencyGraph := loadGraph(command)
+	broken := dependencyGraph.brokenDependencies()
+
+	if format == output.JSONFormat {
+		output.PrintJSON(broken)
+		return
+	}
+	for _, dependencyEdge := range broken {
+		fmt.Printf("%s depends on %s\n", formatEdge(dependencyEdge, dependencyEdge.From), formatNode(dependencyGraph.libraries[dependencyEdge.To]))
+	}
+	fmt.Printf("%d dependencies on missing or retired libraries\n", len(broken))
+}
+
+// Export executes the `deps export` command.
+func Export(command *cobra.Command, cliArguments []string) {
+	format := output.GetFormat(command.Flags(), output.DOTFormat, output.JSONFormat)
+	dependencyGraph := loadGraph(command)
+
+	if format == output.JSONFormat {
+		output.PrintJSON(graphExport{
+			Libraries:    dependencyGraph.nodes(),
+			Dependencies: dependencyGraph.edges(),
+		})
+		return
+	}
+	dependencyGraph.writeDOT(os.Stdout)
+}
+
+// loadGraph loads the database and returns its dependency graph.
+func loadGraph(command *cobra.Command) *graph {
+	config := configuration.ReadConf(command.Flags())
+	librariesDb, err := db.LoadExisting(config.LibrariesDBBackend, config.LibrariesDB)
+	if err != nil {
+		feedback.Errorf("While loading database: %s", err)
+		os.Exit(1)
+	}
+
+	dependencyGraph, err := newGraph(librariesDb)
+	if err != nil {
+		feedback.Errorf("While building dependency graph: %s", err)
+		os.Exit(1)
+	}
+	return dependencyGraph
+}
+
+// printShow writes the dependencies and dependents of a library in human readable format.
+func printShow(output io.Writer, dependencyGraph *graph, result *libraryDependencies) {
+	fmt.Fprintf(output, "%s depends on:\n", formatNode(dependencyGraph.libraries[result.Name]))
+	for _, dependencyEdge := range result.Dependencies {
+		fmt.Fprintf(output, "  %s\n", formatEdge(dependencyEdge, dependencyEdge.To))
+	}
+	if len(result.Dependencies) == 0 {
+		fmt.Fprintln(output, "  none")
+	}
+
+	fmt.Fprintf(output, "Libraries depending on %s:\n", result.Name)
+	for _, dependencyEdge := range result.Dependents {
+		fmt.Fprintf(output, "  %s\n", formatEdge(dependencyEdge, dependencyEdge.From))
+	}
+	if len(result.Dependents) == 0 {
+		fmt.Fprintln(output, "  none")
+	}
+}
+
+// formatNode returns the library name with its status.
+func formatNode(libraryNode *node) string {
+	switch {
+	case libraryNode.Missing:
+		return libraryNode.Name + " (missing)"
+	case libraryNode.Retired:
+		return libraryNode.Name + " (retired)"
+	default:
+		return libraryNode.Name
+	}
+}
+
+// formatEdge returns the name of the library at one end of the dependency, with the constraints and releases of the
+// dependency.
+func formatEdge(dependencyEdge *edge, name string) string {
+	formatted := name
+	if constraints := nonEmpty(dependencyEdge.Constraints); len(constraints) > 0 {
+		formatted += " (" + strings.Join(constraints, " | ") + ")"
+	}
+	return formatted + " in releases " + strings.Join(dependencyEdge.Releases, ", ")
+}
@@ -0,0 +1,265 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package deps
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+)
+
+// retiredType is the type of the libraries which are no longer maintained.
+const retiredType = "Retired"
+
+// graph is the dependency graph of the indexed releases.
+type graph struct {
+	libraries map[string]*node
+}
+
+// node is a library of the dependency graph.
+type node struct {
+	Name    string `json:"name"`
+	Missing bool   `json:"missing,omitempty"` // The library is not in the database.
+	Retired bool   `json:"retired,omitempty"` // The latest release of the library has the Retired type.
+
+	dependencies map[string]*edge // Mapped by the name of the dependency.
+	dependents   map[string]*edge // Mapped by the name of the dependent library.
+}
+
+// edge is the dependency of a library on another.
+type edge struct {
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Releases    []string `json:"releases"`    // Versions of the dependent library having the dependency.
+	Constraints []string `json:"constraints"` // Version constraints of the dependency. Empty string for any version.
+}
+
+// newGraph builds the dependency graph of the indexed releases of the database.
+func newGraph(librariesDb *db.DB) (*graph, error) {
+	dependencyGraph := graph{libraries: make(map[string]*node)}
+	for _, library := range librariesDb.Libraries {
+		latest, err := librariesDb.FindLatestReleaseOfLibrary(library)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil {
+			continue
+		}
+		dependencyGraph.node(library.Name).Retired = slices.Contains(latest.Types, retiredType)
+
+		releases := librariesDb.FindReleasesOfLibrary(library)
+		sort.Slice(releases, func(i, j int) bool { return releases[i].Version.Compare(releases[j].Version) < 0 })
+		for _, release := range releases {
+			if !release.Indexable() {
+				continue
+			}
+			for _, dependency := range release.Dependencies {
+				dependencyGraph.addDependency(library.Name, release.Version.String(), dependency)
+			}
+		}
+	}
+
+	for _, libraryNode := range dependencyGraph.libraries {
+		libraryNode.Missing = !librariesDb.HasLibrary(libraryNode.Name)
+	}
+
+	return &dependencyGraph, nil
+}
+
+// node returns the node of the library, adding it to the graph if necessary.
+func (dependencyGraph *graph) node(name string) *node {
+	libraryNode, found := dependencyGraph.libraries[name]
+	if !found {
+		libraryNode = &node{
+			Name:         name,
+			dependencies: make(map[string]*edge),
+			dependents:   make(map[string]*edge),
+		}
+		dependencyGraph.libraries[name] = libraryNode
+	}
+	return libraryNode
+}
+
+// addDependency adds the dependency of the release of the library to the graph.
+func (dependencyGraph *graph) addDependency(libraryName string, version string, dependency *db.Dependency) {
+	from := dependencyGraph.node(libraryName)
+	to := dependencyGraph.node(dependency.Name)
+	dependencyEdge, found := from.dependencies[to.Name]
+	if !found {
+		dependencyEdge = &edge{From: from.Name, To: to.Name, Releases: []string{}, Constraints: []string{}}
+		from.dependencies[to.Name] = dependencyEdge
+		to.dependents[from.Name] = dependencyEdge
+	}
+	if !slices.Contains(dependencyEdge.Releases, version) {
+		dependencyEdge.Releases = append(dependencyEdge.Releases, version)
+	}
+	if !slices.Contains(dependencyEdge.Constraints, dependency.Version) {
+		dependencyEdge.Constraints = append(dependencyEdge.Constraints, dependency.Version)
+	}
+}
+
+// nodes returns the nodes of the graph sorted by name.
+func (dependencyGraph *graph) nodes() []*node {
+	nodes := []*node{}
+	for _, libraryNode := range dependencyGraph.libraries {
+		nodes = append(nodes, libraryNode)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+// edges returns the dependencies of all the libraries of the graph, sorted by dependent library and dependency name.
+func (dependencyGraph *graph) edges() []*edge {
+	edges := []*edge{}
+	for _, libraryNode := range dependencyGraph.nodes() {
+		edges = append(edges, sortedEdges(libraryNode.dependencies)...)
+	}
+	return edges
+}
+
+// sortedEdges returns the edges of the map sorted by key.
+func sortedEdges(edgeMap map[string]*edge) []*edge {
+	names := []string{}
+	for name := range edgeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	edges := []*edge{}
+	for _, name := range names {
+		edges = append(edges, edgeMap[name])
+	}
+	return edges
+}
+
+// cycles returns the groups of libraries which depend on each other, directly or indirectly. Each cycle is sorted by
+// library name.
+func (dependencyGraph *graph) cycles() [][]string {
+	// Tarjan's strongly connected components algorithm.
+	index := 0
+	indexes := make(map[string]int)
+	lowLinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := []string{}
+	cycles := [][]string{}
+
+	var connect func(libraryNode *node)
+	connect = func(libraryNode *node) {
+		indexes[libraryNode.Name] = index
+		lowLinks[libraryNode.Name] = index
+		index++
+		stack = append(stack, libraryNode.Name)
+		onStack[libraryNode.Name] = true
+
+		for _, dependencyEdge := range sortedEdges(libraryNode.dependencies) {
+			if _, visited := indexes[dependencyEdge.To]; !visited {
+				connect(dependencyGraph.libraries[dependencyEdge.To])
+				lowLinks[libraryNode.Name] = min(lowLinks[libraryNode.Name], lowLinks[dependencyEdge.To])
+			} else if onStack[dependencyEdge.To] {
+				lowLinks[libraryNode.Name] = min(lowLinks[libraryNode.Name], indexes[dependencyEdge.To])
+			}
+		}
+
+		if lowLinks[libraryNode.Name] == indexes[libraryNode.Name] {
+			component := []string{}
+			for {
+				name := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[name] = false
+				component = append(component, name)
+				if name == libraryNode.Name {
+					break
+				}
+			}
+			_, selfDependency := libraryNode.dependencies[libraryNode.Name]
+			if len(component) > 1 || selfDependency {
+				sort.Strings(component)
+				cycles = append(cycles, component)
+			}
+		}
+	}
+
+	for _, libraryNode := range dependencyGraph.nodes() {
+		if _, visited := indexes[libraryNode.Name]; !visited {
+			connect(libraryNode)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// brokenDependencies returns the dependencies on libraries which are missing from the database or retired.
+func (dependencyGraph *graph) brokenDependencies() []*edge {
+	broken := []*edge{}
+	for _, dependencyEdge := range dependencyGraph.edges() {
+		to := dependencyGraph.libraries[dependencyEdge.To]
+		if to.Missing || to.Retired {
+			broken = append(broken, dependencyEdge)
+		}
+	}
+	return broken
+}
+
+// writeDOT writes the graph in the Graphviz DOT format.
+func (dependencyGraph *graph) writeDOT(output io.Writer) {
+	fmt.Fprintln(output, "digraph dependencies {")
+	for _, libraryNode := range dependencyGraph.nodes() {
+		attributes := ""
+		switch {
+		case libraryNode.Missing:
+			attributes = " [style=dashed]"
+		case libraryNode.Retired:
+			attributes = " [color=gray]"
+		}
+		fmt.Fprintf(output, "  %s%s;\n", quoteDOT(libraryNode.Name), attributes)
+	}
+	for _, dependencyEdge := range dependencyGraph.edges() {
+		attributes := ""
+		if constraints := strings.Join(nonEmpty(dependencyEdge.Constraints), " | "); constraints != "" {
+			attributes = " [label=" + quoteDOT(constraints) + "]"
+		}
+		fmt.Fprintf(output, "  %s -> %s%s;\n", quoteDOT(dependencyEdge.From), quoteDOT(dependencyEdge.To), attributes)
+	}
+	fmt.Fprintln(output, "}")
+}
+
+// quoteDOT returns the string as a DOT quoted identifier.
+func quoteDOT(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func nonEmpty(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package deps
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph(t *testing.T) *graph {
+	librariesDb := db.New(filepath.Join(t.TempDir(), "db.json"))
+	addRelease := func(name string, version string, types []string, dependencies ...*db.Dependency) {
+		repository := "https://github.com/Bar/" + name + ".git"
+		if !librariesDb.HasLibrary(name) {
+			require.NoError(t, librariesDb.AddLibrary(&db.Library{Name: name, Repository: repository}))
+		}
+		require.NoError(t, librariesDb.AddRelease(&db.Release{
+			LibraryName:  name,
+			Version:      db.VersionFromString(version),
+			Types:        types,
+			Size:         123,
+			Checksum:     "SHA-256:887f897cfb1818a53652aef39c2a4b8de3c69c805520b2953a562a787b422420",
+			Dependencies: dependencies,
+		}, repository))
+	}
+	contributed := []string{"Contributed"}
+	addRelease("FooLib", "1.0.0", contributed, &db.Dependency{Name: "BarLib", Version: ">=1.0.0"})
+	addRelease("FooLib", "1.1.0", contributed, &db.Dependency{Name: "BarLib", Version: ">=1.1.0"}, &db.Dependency{Name: "MissingLib"})
+	addRelease("BarLib", "1.0.0", contributed)
+	addRelease("BarLib", "1.1.0", []string{"Retired"}, &db.Dependency{Name: "BazLib"})
+	addRelease("BazLib", "1.0.0", contributed, &db.Dependency{Name: "QuxLib"})
+	addRelease("QuxLib", "1.0.0", contributed, &db.Dependency{Name: "BazLib"})
+	addRelease("SelfLib", "1.0.0", contributed, &db.Dependency{Name: "SelfLib"})
+
+	dependencyGraph, err := newGraph(librariesDb)
+	require.NoError(t, err)
+	return dependencyGraph
+}
+
+func TestGraph(t *testing.T) {
+	dependencyGraph := testGraph(t)
+
+	fooLib := dependencyGraph.libraries["FooLib"]
+	require.Len(t, fooLib.dependencies, 2)
+	assert.Equal(t, []string{"1.0.0", "1.1.0"}, fooLib.dependencies["BarLib"].Releases)
+	assert.Equal(t, []string{">=1.0.0", ">=1.1.0"}, fooLib.dependencies["BarLib"].Constraints)
+	assert.Equal(t, []string{"1.1.0"}, fooLib.dependencies["MissingLib"].Releases)
+	assert.Empty(t, fooLib.dependents)
+	assert.Len(t, dependencyGraph.libraries["BarLib"].dependents, 1)
+	assert.True(t, dependencyGraph.libraries["BarLib"].Retired)
+	assert.True(t, dependencyGraph.libraries["MissingLib"].Missing)
+
+	assert.Equal(t, [][]string{{"BazLib", "QuxLib"}, {"SelfLib"}}, dependencyGraph.cycles())
+
+	broken := dependencyGraph.brokenDependencies()
+	require.Len(t, broken, 2)
+	assert.Equal(t, "BarLib", broken[0].To)
+	assert.Equal(t, "MissingLib", broken[1].To)
+}
+
+func TestPrintShow(t *testing.T) {
+	dependencyGraph := testGraph(t)
+	barLib := dependencyGraph.libraries["BarLib"]
+	var output bytes.Buffer
+	printShow(&output, dependencyGraph, &libraryDependencies{
+		Name:         barLib.Name,
+		Dependencies: sortedEdges(barLib.dependencies),
+		Dependents:   sortedEdges(barLib.dependents),
+	})
+	assert.Equal(t, `BarLib (retired) depends on:
+  BazLib in releases 1.1.0
+Libraries depending on BarLib:
+  FooLib (>=1.0.0 | >=1.1.0) in releases 1.0.0, 1.1.0
+`, output.String())
+}
+
+func TestWriteDOT(t *testing.T) {
+	dependencyGraph := graph{libraries: make(map[string]*node)}
+	dependencyGraph.addDependency("FooLib", "1.0.0", &db.Dependency{Name: `Bar "Lib"`, Version: ">=1.0.0"})
+	dependencyGraph.addDependency("FooLib", "1.0.0", &db.Dependency{Name: "BazLib"})
+	dependencyGraph.libraries["BazLib"].Missing = true
+
+	var output bytes.Buffer
+	dependencyGraph.writeDOT(&output)
+	assert.Equal(t, `digraph dependencies {
+  "Bar \"Lib\"";
+  "BazLib" [style=dashed];
+  "FooLib";
+  "FooLib" -> "Bar \"Lib\"" [label=">=1.0.0"];
+  "FooLib" -> "BazLib";
+}
+`, output.String())
+}
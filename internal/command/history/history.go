@@ -0,0 +1,102 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package history implements the `history` CLI subcommand used by the maintainer to review the administrative
+// operations performed on a library.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/arduino/libraries-repository-engine/internal/audit"
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/spf13/cobra"
+)
+
+// Run executes the command.
+func Run(command *cobra.Command, cliArguments []string) {
+	config := configuration.ReadConf(command.Flags())
+	libraryName := cliArguments[0]
+	jsonOutput, err := command.Flags().GetBool("json")
+	if err != nil {
+		panic(err)
+	}
+
+	entries, err := audit.History(audit.Path(config), libraryName)
+	if err != nil && !os.IsNotExist(err) {
+		feedback.Errorf("While reading audit log: %s", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				panic(err)
+			}
+		}
+		return
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No history for library %s\n", libraryName)
+		return
+	}
+	for _, entry := range entries {
+		fmt.Print(format(entry))
+	}
+}
+
+// format returns the human readable representation of the entry.
+func format(entry *audit.Entry) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s %s by %s@%s: %s\n", entry.Time.Format(time.RFC3339), entry.Command, entry.User, entry.Host, strings.Join(entry.Arguments, " "))
+	for _, change := range entry.Changes {
+		if change.Field == "" && change.After == nil {
+			fmt.Fprintf(&builder, "  %s removed\n", change.Object)
+			continue
+		}
+		fmt.Fprintf(&builder, "  %s %s: %s -> %s\n", change.Object, change.Field, formatValue(change.Before), formatValue(change.After))
+	}
+	for _, file := range entry.Files {
+		if file.NewPath != "" {
+			fmt.Fprintf(&builder, "  %s %s -> %s\n", file.Operation, file.Path, file.NewPath)
+		} else {
+			fmt.Fprintf(&builder, "  %s %s\n", file.Operation, file.Path)
+		}
+	}
+	return builder.String()
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,54 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arduino/libraries-repository-engine/internal/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	entry := &audit.Entry{
+		Time:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		User:      "jane",
+		Host:      "server",
+		Command:   "modify",
+		Arguments: []string{"modify", "--types", "Arduino", "FooLib"},
+		Library:   "FooLib",
+	}
+	entry.AddChange("release 1.0.0", "Types", []string{"Contributed"}, []string{"Arduino"})
+	entry.AddChange("release 0.1.0", "", map[string]string{"LibraryName": "FooLib"}, nil)
+	entry.AddFileOperation(audit.FileMoved, "/a/FooLib-1.0.0.zip", "/b/FooLib-1.0.0.zip")
+	entry.AddFileOperation(audit.FileDeleted, "/a/FooLib-0.1.0.zip", "")
+
+	assert.Equal(t, `2026-01-02T03:04:05Z modify by jane@server: modify --types Arduino FooLib
+  release 1.0.0 Types: ["Contributed"] -> ["Arduino"]
+  release 0.1.0 removed
+  moved /a/FooLib-1.0.0.zip -> /b/FooLib-1.0.0.zip
+  deleted /a/FooLib-0.1.0.zip
+`, format(entry))
+}
@@ -0,0 +1,110 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package migrate implements the `db migrate` CLI subcommand used by the maintainer to change the storage backend of
+// the libraries database.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/arduino/libraries-repository-engine/internal/lock"
+	"github.com/spf13/cobra"
+)
+
+// Run executes the command.
+func Run(command *cobra.Command, cliArguments []string) {
+	config := configuration.ReadConf(command.Flags())
+	backend := cliArguments[0]
+	destinationPath := paths.New(cliArguments[1])
+
+	dataLock, err := lock.AcquireForConfig(config, command.Flags(), "db migrate")
+	if err != nil {
+		feedback.Error(err)
+		os.Exit(1)
+	}
+	defer dataLock.Release()
+
+	exist, err := destinationPath.ExistCheck()
+	if err != nil {
+		feedback.Errorf("While checking existence of destination database file: %s", err)
+		dataLock.Exit(1)
+	}
+	if exist {
+		feedback.Errorf("Destination database file %s already exists", destinationPath)
+		dataLock.Exit(1)
+	}
+
+	sourceStorage, err := db.OpenStorage(config.LibrariesDBBackend, config.LibrariesDB)
+	if err != nil {
+		feedback.Errorf("While opening database: %s", err)
+		dataLock.Exit(1)
+	}
+	sourceDb, err := db.Open(sourceStorage)
+	if err != nil {
+		feedback.Errorf("While loading database: %s", err)
+		dataLock.Exit(1)
+	}
+	defer sourceDb.Close()
+
+	if err := migrate(sourceDb, backend, destinationPath.String()); err != nil {
+		feedback.Errorf("While migrating database: %s", err)
+		if err := destinationPath.Remove(); err != nil && !os.IsNotExist(err) {
+			feedback.Errorf("While removing incomplete destination database: %s", err)
+		}
+		dataLock.Exit(1)
+	}
+
+	fmt.Printf("Migrated %d libraries, %d releases and %d rejected releases to %s database %s\n",
+		len(sourceDb.Libraries), len(sourceDb.Releases), len(sourceDb.RejectedReleases), backend, destinationPath)
+}
+
+// migrate saves the content of the database to a new storage and checks the result.
+func migrate(sourceDb *db.DB, backend string, destinationPath string) error {
+	destinationStorage, err := db.OpenStorage(backend, destinationPath)
+	if err != nil {
+		return err
+	}
+	defer destinationStorage.Close()
+
+	if err := sourceDb.SaveToStorage(destinationStorage); err != nil {
+		return err
+	}
+
+	destinationDb, err := db.LoadFromStorage(destinationStorage)
+	if err != nil {
+		return err
+	}
+	if len(destinationDb.Libraries) != len(sourceDb.Libraries) ||
+		len(destinationDb.Releases) != len(sourceDb.Releases) ||
+		len(destinationDb.RejectedReleases) != len(sourceDb.RejectedReleases) {
+		return errors.New("destination database content doesn't match the source")
+	}
+	return nil
+}
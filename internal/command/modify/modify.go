@@ -30,6 +30,7 @@ import (
 	"strings"
 
 	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/libraries-repository-engine/internal/audit"
 	"github.com/arduino/libraries-repository-engine/internal/backup"
 	"github.com/arduino/libraries-repository-engine/internal/configuration"
 	"github.com/arduino/libraries-repository-engine/internal/feedback"
@@ -37,6 +38,8 @@ import (
 	"github.com/arduino/libraries-repository-engine/internal/libraries/archive"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/syncstate"
+	"github.com/arduino/libraries-repository-engine/internal/lock"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -45,6 +48,8 @@ var config *configuration.Config
 var libraryName string
 var libraryData *db.Library
 var releasesData []*db.Release
+var oldRepositoryURL string
+var auditEntry *audit.Entry
 
 // Run executes the command.
 func Run(command *cobra.Command, cliArguments []string) {
@@ -52,28 +57,45 @@ func Run(command *cobra.Command, cliArguments []string) {
 	config = configuration.ReadConf(command.Flags())
 
 	libraryName = cliArguments[0]
+	auditEntry = audit.NewEntry("modify", libraryName)
+
+	dataLock, err := lock.AcquireForConfig(config, command.Flags(), "modify")
+	if err != nil {
+		feedback.Error(err)
+		os.Exit(1)
+	}
+	defer dataLock.Release()
 
 	librariesDBPath := paths.New(config.LibrariesDB)
 	exist, err := librariesDBPath.ExistCheck()
 	if err != nil {
 		feedback.Errorf("While checking existence of database file: %s", err)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 	if !exist {
 		feedback.Errorf("Database file not found at %s. Check the LibrariesDB configuration value.", librariesDBPath)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	if err := backup.Backup(librariesDBPath); err != nil {
 		feedback.Errorf("While backing up database: %s", err)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	// Load all the library's data from the DB.
-	librariesDb := db.Init(librariesDBPath.String())
+	dbStorage, err := db.OpenStorage(config.LibrariesDBBackend, librariesDBPath.String())
+	if err != nil {
+		feedback.Errorf("While opening database: %s", err)
+		dataLock.Exit(1)
+	}
+	librariesDb, err := db.InitWithStorage(dbStorage)
+	if err != nil {
+		feedback.Errorf("While loading database: %s", err)
+		dataLock.Exit(1)
+	}
 	if !librariesDb.HasLibrary(libraryName) {
 		feedback.Errorf("Library of name %s not found", libraryName)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 	libraryData, err = librariesDb.FindLibrary(libraryName)
 	if err != nil {
@@ -94,7 +116,7 @@ func Run(command *cobra.Command, cliArguments []string) {
 				feedback.Errorf("While cleaning up the backup content: %s", err)
 			}
 		}
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	if err := librariesDb.Commit(); err != nil {
@@ -103,12 +125,31 @@ func Run(command *cobra.Command, cliArguments []string) {
 			feedback.Errorf("While restoring the content from backup: %s", err)
 		}
 		fmt.Println("Original files were restored.")
-		os.Exit(1)
+		dataLock.Exit(1)
+	}
+	if err := librariesDb.Close(); err != nil {
+		feedback.Errorf("While closing database: %s", err)
+	}
+
+	if err := audit.Append(audit.Path(config), auditEntry); err != nil {
+		feedback.Errorf("While recording changes in audit log: %s", err)
+		if err := backup.Restore(); err != nil {
+			feedback.Errorf("While restoring the content from backup: %s", err)
+		}
+		fmt.Println("Original files were restored.")
+		dataLock.Exit(1)
+	}
+
+	if config.SyncStateFile != "" && oldRepositoryURL != "" {
+		// The state of the old URL is obsolete.
+		if err := syncstate.ForgetInFile(config.SyncStateFile, oldRepositoryURL); err != nil {
+			feedback.Warningf("While updating sync state: %s", err)
+		}
 	}
 
 	if err := backup.Clean(); err != nil {
 		feedback.Errorf("While cleaning up the backup files: %s", err)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	fmt.Println("Success!")
@@ -158,7 +199,7 @@ func modifyRepositoryURL(newRepositoryURL string) error {
 		return fmt.Errorf("Library %s already has URL %s", libraryName, newRepositoryURL)
 	}
 
-	oldRepositoryURL := libraryData.Repository
+	oldRepositoryURL = libraryData.Repository
 
 	fmt.Printf("Changing URL of library %s from %s to %s\n", libraryName, oldRepositoryURL, newRepositoryURL)
 
@@ -169,6 +210,7 @@ func modifyRepositoryURL(newRepositoryURL string) error {
 
 	// Update the library repository URL in the database.
 	libraryData.Repository = newRepositoryURL
+	auditEntry.AddChange("library", "Repository", oldRepositoryURL, newRepositoryURL)
 
 	// Update library releases.
 	oldRepositoryObject := libraries.Repository{URL: oldRepositoryURL}
@@ -197,8 +239,10 @@ func modifyRepositoryURL(newRepositoryURL string) error {
 		if err := oldArchiveObjectPath.Rename(newArchiveObjectPath); err != nil {
 			return fmt.Errorf("While moving library release archive: %w", err)
 		}
+		auditEntry.AddFileOperation(audit.FileMoved, oldArchiveObjectPath.String(), newArchiveObjectPath.String())
 
 		// Update the release download URL in the database.
+		auditEntry.AddChange("release "+releaseData.Version.String(), "URL", releaseData.URL, newArchiveObject.URL)
 		releaseData.URL = newArchiveObject.URL
 	}
 
@@ -235,9 +279,10 @@ func modifyTypes(rawTypes string) error {
 	typesChanged := false
 
 	for _, releaseData := range releasesData {
-		if !typesChanged {
-			// Compare old and new types for this release
-			typesChanged = !sameTypes(releaseData.Types)
+		// Compare old and new types for this release
+		if !sameTypes(releaseData.Types) {
+			typesChanged = true
+			auditEntry.AddChange("release "+releaseData.Version.String(), "Types", releaseData.Types, newTypes)
 		}
 
 		releaseData.Types = newTypes
@@ -0,0 +1,191 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package query implements the read-only `list`, `show` and `search` CLI subcommands used to inspect the libraries
+// database.
+package query
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/arduino/libraries-repository-engine/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// filter selects libraries according to their repository and the properties of their latest release. Empty fields
+// match any library.
+type filter struct {
+	libraryType    string
+	category       string
+	architecture   string
+	maintainer     string // Matched as a substring.
+	repositoryHost string
+}
+
+// librarySummary is the output of the list and search commands for a library.
+type librarySummary struct {
+	Name          string   `json:"name"`
+	LatestVersion string   `json:"latestVersion"`
+	Category      string   `json:"category"`
+	Types         []string `json:"types"`
+	Architectures []string `json:"architectures"`
+	Maintainer    string   `json:"maintainer"`
+	Repository    string   `json:"repository"`
+}
+
+// AddFilterFlags adds the flags used to filter the libraries to the command.
+func AddFilterFlags(command *cobra.Command) {
+	command.Flags().String("type", "", "Only libraries of this type (e.g. Contributed)")
+	command.Flags().String("category", "", "Only libraries of this category")
+	command.Flags().String("architecture", "", "Only libraries compatible with this architecture")
+	command.Flags().String("maintainer", "", "Only libraries whose maintainer contains this text")
+	command.Flags().String("repository-host", "", "Only libraries hosted on this site (e.g. github.com)")
+}
+
+// AddFormatFlag adds the flag used to select the output format to the command.
+func AddFormatFlag(command *cobra.Command) {
+	command.Flags().String("format", output.TableFormat, "Output format: table or json")
+}
+
+// List executes the `list` command.
+func List(command *cobra.Command, cliArguments []string) {
+	search(command, "")
+}
+
+// Search executes the `search` command.
+func Search(command *cobra.Command, cliArguments []string) {
+	search(command, cliArguments[0])
+}
+
+func search(command *cobra.Command, query string) {
+	config := configuration.ReadConf(command.Flags())
+	libraryFilter := getFilter(command.Flags())
+	format := output.GetFormat(command.Flags(), output.TableFormat, output.JSONFormat)
+	librariesDb, err := db.LoadExisting(config.LibrariesDBBackend, config.LibrariesDB)
+	if err != nil {
+		feedback.Errorf("While loading database: %s", err)
+		os.Exit(1)
+	}
+
+	summaries := []*librarySummary{}
+	for _, library := range librariesDb.Libraries {
+		latest, err := librariesDb.FindLatestReleaseOfLibrary(library)
+		if err != nil {
+			feedback.Errorf("While finding latest release of %s: %s", library.Name, err)
+			os.Exit(1)
+		}
+		if latest == nil || !libraryFilter.matches(library, latest) || !matchesQuery(latest, query) {
+			continue
+		}
+		summaries = append(summaries, summarize(library, latest))
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+
+	if format == output.JSONFormat {
+		output.PrintJSON(summaries)
+		return
+	}
+	writer := newTableWriter(os.Stdout)
+	fmt.Fprintln(writer, "NAME\tVERSION\tCATEGORY\tTYPES\tREPOSITORY")
+	for _, summary := range summaries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", summary.Name, summary.LatestVersion, summary.Category, strings.Join(summary.Types, ","), summary.Repository)
+	}
+	writer.Flush()
+}
+
+func getFilter(flags *pflag.FlagSet) *filter {
+	getString := func(name string) string {
+		value, err := flags.GetString(name)
+		if err != nil {
+			panic(err)
+		}
+		return value
+	}
+	return &filter{
+		libraryType:    getString("type"),
+		category:       getString("category"),
+		architecture:   getString("architecture"),
+		maintainer:     getString("maintainer"),
+		repositoryHost: getString("repository-host"),
+	}
+}
+
+// matches returns whether the library matches the filter.
+func (libraryFilter *filter) matches(library *db.Library, latest *db.Release) bool {
+	if libraryFilter.libraryType != "" && !output.ContainsFold(latest.Types, libraryFilter.libraryType) {
+		return false
+	}
+	if libraryFilter.category != "" && !strings.EqualFold(library.LatestCategory, libraryFilter.category) {
+		return false
+	}
+	if libraryFilter.architecture != "" && !output.ContainsFold(latest.Architectures, libraryFilter.architecture) && !output.ContainsFold(latest.Architectures, "*") {
+		return false
+	}
+	if libraryFilter.maintainer != "" && !strings.Contains(strings.ToLower(latest.Maintainer), strings.ToLower(libraryFilter.maintainer)) {
+		return false
+	}
+	if libraryFilter.repositoryHost != "" {
+		repositoryURL, err := url.Parse(library.Repository)
+		if err != nil || !strings.EqualFold(repositoryURL.Host, libraryFilter.repositoryHost) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesQuery returns whether the text fields of the release contain the query, ignoring case.
+func matchesQuery(release *db.Release, query string) bool {
+	query = strings.ToLower(query)
+	for _, field := range []string{release.LibraryName, release.Sentence, release.Paragraph, release.Author, release.Maintainer} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func summarize(library *db.Library, latest *db.Release) *librarySummary {
+	return &librarySummary{
+		Name:          library.Name,
+		LatestVersion: latest.Version.String(),
+		Category:      library.LatestCategory,
+		Types:         latest.Types,
+		Architectures: latest.Architectures,
+		Maintainer:    latest.Maintainer,
+		Repository:    library.Repository,
+	}
+}
+
+func newTableWriter(output io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+}
@@ -0,0 +1,121 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package query
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	library := &db.Library{
+		Name:           "FooLib",
+		Repository:     "https://github.com/Bar/FooLib.git",
+		LatestCategory: "Display",
+	}
+	latest := &db.Release{
+		LibraryName:   "FooLib",
+		Version:       db.VersionFromString("1.0.0"),
+		Maintainer:    "Jane Developer <janedeveloper@example.com>",
+		Sentence:      "Drives the Foo display.",
+		Architectures: []string{"avr", "samd"},
+		Types:         []string{"Contributed"},
+	}
+
+	testTable := []struct {
+		name    string
+		filter  filter
+		matches bool
+	}{
+		{"No filter", filter{}, true},
+		{"Type", filter{libraryType: "contributed"}, true},
+		{"Other type", filter{libraryType: "Arduino"}, false},
+		{"Category", filter{category: "display"}, true},
+		{"Other category", filter{category: "Sensors"}, false},
+		{"Architecture", filter{architecture: "samd"}, true},
+		{"Other architecture", filter{architecture: "esp32"}, false},
+		{"Maintainer", filter{maintainer: "jane"}, true},
+		{"Other maintainer", filter{maintainer: "john"}, false},
+		{"Repository host", filter{repositoryHost: "github.com"}, true},
+		{"Other repository host", filter{repositoryHost: "gitlab.com"}, false},
+		{"Combined", filter{libraryType: "Contributed", architecture: "esp32"}, false},
+	}
+	for _, testData := range testTable {
+		assert.Equal(t, testData.matches, testData.filter.matches(library, latest), testData.name)
+	}
+
+	latest.Architectures = []string{"*"}
+	assert.True(t, (&filter{architecture: "esp32"}).matches(library, latest), "Compatible with all architectures")
+}
+
+func TestMatchesQuery(t *testing.T) {
+	release := &db.Release{
+		LibraryName: "FooLib",
+		Sentence:    "Drives the Foo display.",
+		Author:      "Jane Developer",
+	}
+	assert.True(t, matchesQuery(release, ""))
+	assert.True(t, matchesQuery(release, "foolib"))
+	assert.True(t, matchesQuery(release, "DISPLAY"))
+	assert.True(t, matchesQuery(release, "jane"))
+	assert.False(t, matchesQuery(release, "sensor"))
+}
+
+func TestPrintLibrary(t *testing.T) {
+	details := &libraryDetails{
+		Library: &db.Library{Name: "FooLib", Repository: "https://github.com/Bar/FooLib.git", LatestCategory: "Display"},
+		Releases: []*releaseDetails{
+			{
+				Release: &db.Release{
+					LibraryName:   "FooLib",
+					Version:       db.VersionFromString("1.0.0"),
+					Architectures: []string{"avr"},
+					Types:         []string{"Contributed"},
+					Size:          123,
+				},
+				ArchivePath: "/libraries/github.com/Bar/FooLib-1.0.0.zip",
+			},
+		},
+	}
+	output := new(bytes.Buffer)
+	printLibrary(output, details)
+	assert.Equal(t, `Name:        FooLib
+Repository:  https://github.com/Bar/FooLib.git
+Category:    Display
+
+VERSION  TYPES        ARCHITECTURES  SIZE  ARCHIVE
+1.0.0    Contributed  avr            123   /libraries/github.com/Bar/FooLib-1.0.0.zip
+`, output.String())
+
+	output.Reset()
+	details.Releases[0].Dependencies = []*db.Dependency{{Name: "BazLib", Version: ">=1.0.0"}, {Name: "QuxLib"}}
+	details.Releases[0].Log = "Some log messages"
+	printRelease(output, details.Releases[0])
+	assert.Contains(t, output.String(), "Dependencies:   BazLib (>=1.0.0), QuxLib\n")
+	assert.Contains(t, output.String(), "Archive:        /libraries/github.com/Bar/FooLib-1.0.0.zip\n")
+	assert.Contains(t, output.String(), "\nLog:\nSome log messages\n")
+}
@@ -0,0 +1,186 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package query
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/arduino/libraries-repository-engine/internal/libraries"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/archive"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
+	"github.com/arduino/libraries-repository-engine/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// releaseDetails is the output of the show command for a release.
+type releaseDetails struct {
+	*db.Release
+	ArchivePath string
+}
+
+// libraryDetails is the output of the show command for a library.
+type libraryDetails struct {
+	Library  *db.Library
+	Releases []*releaseDetails
+}
+
+// Show executes the `show` command.
+func Show(command *cobra.Command, cliArguments []string) {
+	config := configuration.ReadConf(command.Flags())
+	format := output.GetFormat(command.Flags(), output.TableFormat, output.JSONFormat)
+	librariesDb, err := db.LoadExisting(config.LibrariesDBBackend, config.LibrariesDB)
+	if err != nil {
+		feedback.Errorf("While loading database: %s", err)
+		os.Exit(1)
+	}
+
+	referenceComponents := strings.SplitN(cliArguments[0], "@", 2)
+	libraryName := referenceComponents[0]
+	library, err := librariesDb.FindLibrary(libraryName)
+	if err != nil {
+		feedback.Errorf("Library of name %s not found", libraryName)
+		os.Exit(1)
+	}
+
+	releases := librariesDb.FindReleasesOfLibrary(library)
+	sort.SliceStable(releases, func(i, j int) bool { return releases[i].Version.Compare(releases[j].Version) < 0 })
+	details := &libraryDetails{Library: library}
+	for _, release := range releases {
+		details.Releases = append(details.Releases, getReleaseDetails(config, library, release))
+	}
+
+	if len(referenceComponents) > 1 {
+		version := referenceComponents[1]
+		for _, release := range details.Releases {
+			if release.Version.String() == version {
+				if format == output.JSONFormat {
+					output.PrintJSON(release)
+				} else {
+					printRelease(os.Stdout, release)
+				}
+				return
+			}
+		}
+		feedback.Errorf("Library release %s@%s not found", libraryName, version)
+		os.Exit(1)
+	}
+
+	if format == output.JSONFormat {
+		output.PrintJSON(details)
+	} else {
+		printLibrary(os.Stdout, details)
+	}
+}
+
+func getReleaseDetails(config *configuration.Config, library *db.Library, release *db.Release) *releaseDetails {
+	details := &releaseDetails{Release: release}
+	libraryMetadata := metadata.LibraryMetadata{Name: library.Name, Version: release.Version.String()}
+	if archiveData, err := archive.New(&libraries.Repository{URL: library.Repository}, &libraryMetadata, config); err == nil {
+		details.ArchivePath = archiveData.Path
+	}
+	return details
+}
+
+// printLibrary prints the library and the list of its releases.
+func printLibrary(output io.Writer, details *libraryDetails) {
+	writer := newTableWriter(output)
+	fmt.Fprintf(writer, "Name:\t%s\n", details.Library.Name)
+	fmt.Fprintf(writer, "Repository:\t%s\n", details.Library.Repository)
+	fmt.Fprintf(writer, "Category:\t%s\n", details.Library.LatestCategory)
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "VERSION\tTYPES\tARCHITECTURES\tSIZE\tARCHIVE")
+	for _, release := range details.Releases {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\n", release.Version.String(), strings.Join(release.Types, ","), strings.Join(release.Architectures, ","), release.Size, release.ArchivePath)
+	}
+	writer.Flush()
+}
+
+// printRelease prints all the data of the release.
+func printRelease(output io.Writer, release *releaseDetails) {
+	writer := newTableWriter(output)
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"Name", release.LibraryName},
+		{"Version", release.Version.String()},
+		{"Author", release.Author},
+		{"Maintainer", release.Maintainer},
+		{"License", release.License},
+		{"Sentence", release.Sentence},
+		{"Paragraph", release.Paragraph},
+		{"Website", release.Website},
+		{"Category", release.Category},
+		{"Architectures", strings.Join(release.Architectures, ",")},
+		{"Types", strings.Join(release.Types, ",")},
+		{"Includes", strings.Join(release.Includes, ",")},
+		{"Dependencies", formatDependencies(release.Dependencies)},
+		{"URL", release.URL},
+		{"Archive", release.ArchivePath},
+		{"Size", fmt.Sprint(release.Size)},
+		{"Checksum", release.Checksum},
+		{"Tag", release.Tag},
+		{"Commit", release.CommitHash},
+	}
+	for _, field := range fields {
+		fmt.Fprintf(writer, "%s:\t%s\n", field.name, field.value)
+	}
+	if release.PropertiesUnknown {
+		// Not recorded when the release was indexed.
+		fmt.Fprintln(writer, "Precompiled:\tunknown")
+		fmt.Fprintln(writer, "Linker flags:\tunknown")
+		fmt.Fprintln(writer, "Dot a linkage:\tunknown")
+	}
+	if release.Precompiled != "" {
+		fmt.Fprintf(writer, "Precompiled:\t%s\n", release.Precompiled)
+	}
+	if release.LDFlags != "" {
+		fmt.Fprintf(writer, "Linker flags:\t%s\n", release.LDFlags)
+	}
+	if release.DotALinkage {
+		fmt.Fprintf(writer, "Dot a linkage:\t%t\n", release.DotALinkage)
+	}
+	if !release.IndexTime.IsZero() {
+		fmt.Fprintf(writer, "Indexed:\t%s\n", release.IndexTime.Format("2006-01-02 15:04:05 MST"))
+	}
+	writer.Flush()
+	if release.Log != "" {
+		fmt.Fprintf(output, "\nLog:\n%s\n", release.Log)
+	}
+}
+
+func formatDependencies(dependencies []*db.Dependency) string {
+	formatted := []string{}
+	for _, dependency := range dependencies {
+		formatted = append(formatted, dependency.String())
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -30,6 +30,7 @@ import (
 	"strings"
 
 	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/libraries-repository-engine/internal/audit"
 	"github.com/arduino/libraries-repository-engine/internal/backup"
 	"github.com/arduino/libraries-repository-engine/internal/configuration"
 	"github.com/arduino/libraries-repository-engine/internal/feedback"
@@ -37,12 +38,17 @@ import (
 	"github.com/arduino/libraries-repository-engine/internal/libraries/archive"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/syncstate"
+	"github.com/arduino/libraries-repository-engine/internal/lock"
 	"github.com/spf13/cobra"
 )
 
 var config *configuration.Config
 var librariesDb *db.DB
 var libraryData *db.Library
+var removedRepositories []string
+var auditEntries []*audit.Entry
+var auditEntry *audit.Entry // Entry of the library being processed.
 
 // Run executes the command.
 func Run(command *cobra.Command, cliArguments []string) {
@@ -53,23 +59,39 @@ func Run(command *cobra.Command, cliArguments []string) {
 		os.Exit(1)
 	}
 
+	dataLock, err := lock.AcquireForConfig(config, command.Flags(), "remove")
+	if err != nil {
+		feedback.Error(err)
+		os.Exit(1)
+	}
+	defer dataLock.Release()
+
 	librariesDBPath := paths.New(config.LibrariesDB)
 	exist, err := librariesDBPath.ExistCheck()
 	if err != nil {
 		feedback.Errorf("While checking existence of database file: %s", err)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 	if !exist {
 		feedback.Errorf("Database file not found at %s. Check the LibrariesDB configuration value.", librariesDBPath)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	if err := backup.Backup(librariesDBPath); err != nil {
 		feedback.Errorf("While backing up database: %s", err)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
-	librariesDb = db.Init(config.LibrariesDB)
+	dbStorage, err := db.OpenStorage(config.LibrariesDBBackend, config.LibrariesDB)
+	if err != nil {
+		feedback.Errorf("While opening database: %s", err)
+		dataLock.Exit(1)
+	}
+	librariesDb, err = db.InitWithStorage(dbStorage)
+	if err != nil {
+		feedback.Errorf("While loading database: %s", err)
+		dataLock.Exit(1)
+	}
 
 	restore, err := removals(cliArguments)
 	if err != nil {
@@ -84,7 +106,7 @@ func Run(command *cobra.Command, cliArguments []string) {
 				feedback.Errorf("While cleaning up the backup content: %s", err)
 			}
 		}
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	if err := librariesDb.Commit(); err != nil {
@@ -93,12 +115,31 @@ func Run(command *cobra.Command, cliArguments []string) {
 			feedback.Errorf("While restoring the content from backup: %s", err)
 		}
 		fmt.Println("Original files were restored.")
-		os.Exit(1)
+		dataLock.Exit(1)
+	}
+	if err := librariesDb.Close(); err != nil {
+		feedback.Errorf("While closing database: %s", err)
+	}
+
+	if err := audit.Append(audit.Path(config), auditEntries...); err != nil {
+		feedback.Errorf("While recording changes in audit log: %s", err)
+		if err := backup.Restore(); err != nil {
+			feedback.Errorf("While restoring the content from backup: %s", err)
+		}
+		fmt.Println("Original files were restored.")
+		dataLock.Exit(1)
+	}
+
+	if config.SyncStateFile != "" {
+		// Make the next sync process the repositories fully.
+		if err := syncstate.ForgetInFile(config.SyncStateFile, removedRepositories...); err != nil {
+			feedback.Warningf("While updating sync state: %s", err)
+		}
 	}
 
 	if err := backup.Clean(); err != nil {
 		feedback.Errorf("While cleaning up the backup files: %s", err)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	fmt.Println("Success!")
@@ -125,6 +166,9 @@ func removals(libraryReferences []string) (bool, error) {
 		if err != nil {
 			return true, err
 		}
+		removedRepositories = append(removedRepositories, libraryData.Repository)
+		auditEntry = audit.NewEntry("remove", libraryName)
+		auditEntries = append(auditEntries, auditEntry)
 
 		if libraryVersion == "" {
 			// Remove the library entirely.
@@ -157,6 +201,10 @@ func removeLibrary(libraryName string) error {
 	if err := librariesDb.RemoveLibrary(libraryName); err != nil {
 		return err
 	}
+	auditEntry.AddChange("library", "", libraryData, nil)
+	for _, releaseData := range releasesData {
+		auditEntry.AddChange("release "+releaseData.Version.String(), "", releaseData, nil)
+	}
 
 	// Remove the library Git clone folder.
 	if err := libraries.BackupAndDeleteGitClone(config, &libraries.Repo{URL: libraryData.Repository}); err != nil {
@@ -179,9 +227,14 @@ func removeRelease(libraryName string, version string) error {
 	}
 
 	// Remove the release from the database.
+	releaseData, err := librariesDb.FindRelease(&db.Release{LibraryName: libraryName, Version: db.VersionFromString(version)})
+	if err != nil {
+		return err
+	}
 	if err := librariesDb.RemoveReleaseByNameVersion(libraryName, version); err != nil {
 		return err
 	}
+	auditEntry.AddChange("release "+version, "", releaseData, nil)
 
 	return nil
 }
@@ -204,6 +257,7 @@ func removeReleaseArchive(version string) error {
 	if err := archivePath.RemoveAll(); err != nil {
 		return fmt.Errorf("While removing library release archive: %s", err)
 	}
+	auditEntry.AddFileOperation(audit.FileDeleted, archivePath.String(), "")
 
 	return nil
 }
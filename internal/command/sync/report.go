@@ -0,0 +1,138 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sync
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
+)
+
+// Outcome categories of the sync of a repository.
+const (
+	repositorySynced     = "synced"
+	repositoryUnchanged  = "unchanged"
+	repositoryInvalidURL = "invalid-url"
+	repositoryFetchError = "fetch-error"
+	repositoryTagsError  = "tags-error"
+)
+
+// Outcome categories of the sync of a tag.
+const (
+	tagIndexed            = "indexed"
+	tagWouldIndex         = "would-index" // Dry run.
+	tagAlreadyLoaded      = "already-loaded"
+	tagPreviouslyRejected = "previously-rejected"
+	tagCheckoutError      = "checkout-error"
+	tagMetadataError      = "metadata-error"      // Missing or unparsable library.properties.
+	tagMetadataReadError  = "metadata-read-error" // library.properties could not be read.
+	tagWrongName          = "wrong-name"
+	tagInvalidMetadata    = "invalid-metadata"
+	tagAntivirusFailure   = "antivirus-failure"
+	tagAntivirusError     = "antivirus-error" // The antivirus could not scan the release.
+	tagLintFailure        = "lint-failure"
+	tagLintError          = "lint-error" // Arduino Lint could not check the release.
+	tagArchiveError       = "archive-error"
+	tagDatabaseError      = "database-error"
+)
+
+// syncReport is the machine-readable report of a sync run.
+type syncReport struct {
+	StartTime            time.Time           `json:"startTime"`
+	Duration             float64             `json:"duration"` // Seconds.
+	Repositories         []*repositoryReport `json:"repositories"`
+	NewReleasesCount     int                 `json:"newReleasesCount"` // Includes the releases that would be added by a dry run.
+	LibrariesCount       int                 `json:"librariesCount"`
+	ReleasesCount        int                 `json:"releasesCount"`
+	IndexedReleasesCount int                 `json:"indexedReleasesCount"`
+	// Dependencies of the indexed releases which can't be resolved, mapped by library name.
+	UnresolvedDependencies map[string][]*db.UnresolvedDependency `json:"unresolvedDependencies,omitempty"`
+
+	mutex sync.Mutex
+}
+
+// repositoryReport is the report of the sync of a library repository.
+type repositoryReport struct {
+	URL         string       `json:"url"`
+	LibraryName string       `json:"name"`
+	Outcome     string       `json:"outcome"`
+	Reason      string       `json:"reason,omitempty"`
+	Duration    float64      `json:"duration"` // Seconds.
+	Tags        []*tagReport `json:"tags"`
+	// Number of consecutive failed fetches of the repository, over all sync runs if the sync state is persisted.
+	FetchFailures int `json:"fetchFailures,omitempty"`
+}
+
+// tagReport is the report of the sync of a tag of a library repository.
+type tagReport struct {
+	Tag      string  `json:"tag"`
+	Version  string  `json:"version,omitempty"`
+	Outcome  string  `json:"outcome"`
+	Reason   string  `json:"reason,omitempty"`
+	Duration float64 `json:"duration"` // Seconds.
+	// Problems found by the validation of the library.properties metadata.
+	Findings []*metadata.Finding `json:"findings,omitempty"`
+
+	output string // Output of the failed check.
+}
+
+func newSyncReport() *syncReport {
+	return &syncReport{
+		StartTime:    time.Now().UTC(),
+		Repositories: []*repositoryReport{},
+	}
+}
+
+// addRepository adds the report of a repository. It is safe for concurrent use.
+func (report *syncReport) addRepository(repositoryReport *repositoryReport) {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	report.Repositories = append(report.Repositories, repositoryReport)
+	for _, tagReport := range repositoryReport.Tags {
+		if tagReport.Outcome == tagIndexed || tagReport.Outcome == tagWouldIndex {
+			report.NewReleasesCount++
+		}
+	}
+}
+
+// writeFile writes the report to the given file. The repositories are sorted by URL, since the order in which they are
+// synced is not deterministic.
+func (report *syncReport) writeFile(reportFile string) error {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	report.Duration = time.Since(report.StartTime).Seconds()
+	sort.SliceStable(report.Repositories, func(i, j int) bool {
+		return report.Repositories[i].URL < report.Repositories[j].URL
+	})
+	buff, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(reportFile, buff, 0644)
+}
@@ -0,0 +1,75 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sync
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncReport(t *testing.T) {
+	report := newSyncReport()
+	report.addRepository(&repositoryReport{
+		URL:         "https://github.com/Bar/FooLib.git",
+		LibraryName: "FooLib",
+		Outcome:     repositorySynced,
+		Tags: []*tagReport{
+			{Tag: "1.0.0", Version: "1.0.0", Outcome: tagAlreadyLoaded},
+			{Tag: "1.1.0", Version: "1.1.0", Outcome: tagIndexed},
+			{Tag: "1.2.0", Version: "1.2.0", Outcome: tagLintFailure, Reason: "exit status 1"},
+		},
+	})
+	report.addRepository(&repositoryReport{
+		URL:         "https://github.com/Bar/BazLib.git",
+		LibraryName: "BazLib",
+		Outcome:     repositoryFetchError,
+		Reason:      "repository not found",
+		Tags:        []*tagReport{},
+	})
+	assert.Equal(t, 1, report.NewReleasesCount)
+
+	reportFolder, err := paths.MkTempDir("", "sync-TestSyncReport")
+	require.NoError(t, err)
+	defer reportFolder.RemoveAll()
+	reportPath := reportFolder.Join("report.json")
+	require.NoError(t, report.writeFile(reportPath.String()))
+
+	rawReport, err := reportPath.ReadFile()
+	require.NoError(t, err)
+	var writtenReport map[string]interface{}
+	require.NoError(t, json.Unmarshal(rawReport, &writtenReport))
+	assert.Equal(t, float64(1), writtenReport["newReleasesCount"])
+	repositories := writtenReport["repositories"].([]interface{})
+	require.Len(t, repositories, 2)
+	assert.Equal(t, "https://github.com/Bar/BazLib.git", repositories[0].(map[string]interface{})["url"], "Sorted by URL")
+	assert.Equal(t, "fetch-error", repositories[0].(map[string]interface{})["outcome"])
+	tags := repositories[1].(map[string]interface{})["tags"].([]interface{})
+	require.Len(t, tags, 3)
+	assert.Equal(t, "lint-failure", tags[2].(map[string]interface{})["outcome"])
+	assert.Equal(t, "exit status 1", tags[2].(map[string]interface{})["reason"])
+}
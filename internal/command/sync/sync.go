@@ -29,11 +29,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/arduino/libraries-repository-engine/internal/configuration"
 	"github.com/arduino/libraries-repository-engine/internal/feedback"
@@ -41,18 +46,36 @@ import (
 	"github.com/arduino/libraries-repository-engine/internal/libraries/archive"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/gitutils"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/index"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/syncstate"
+	"github.com/arduino/libraries-repository-engine/internal/lock"
+	"github.com/arduino/libraries-repository-engine/internal/version"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/spf13/cobra"
 )
 
 var config *configuration.Config
+var syncState *syncstate.State
+var selectiveSync bool           // Only the libraries specified by the user are synced.
+var selectedTags map[string]bool // Only these tags are synced if non-empty.
+var reportFile string
+var dryRun bool  // Check new releases without making any changes to the Library Manager content.
+var recheck bool // Check again the tags that were previously rejected.
+var rules string // Identifies the rules applied to releases.
+var arduinoLintVersion string
+var previousIndexFile string // Previously published index to compare the generated index with.
+var indexSigner index.Signer
+var dataLock *lock.Lock // Held for the whole sync.
+
+// rulesVersion must be incremented when a change to the engine affects whether releases are accepted, so that
+// previously rejected tags are checked again.
+const rulesVersion = "2"
 
 // Run executes the command.
 func Run(command *cobra.Command, cliArguments []string) {
 	config = configuration.ReadConf(command.Flags())
 
-	setup(config)
-
 	var reposFile string
 	if len(cliArguments) > 0 {
 		reposFile = cliArguments[0]
@@ -65,22 +88,98 @@ func Run(command *cobra.Command, cliArguments []string) {
 		os.Exit(1)
 	}
 
-	syncLibraries(reposFile)
+	selectedLibraries, err := command.Flags().GetStringArray("library")
+	if err != nil {
+		panic(err)
+	}
+	tags, err := command.Flags().GetStringArray("tag")
+	if err != nil {
+		panic(err)
+	}
+	if len(tags) > 0 && len(selectedLibraries) != 1 {
+		feedback.LogError(errors.New("--tag requires exactly one --library"))
+		os.Exit(1)
+	}
+	reportFile, err = command.Flags().GetString("report")
+	if err != nil {
+		panic(err)
+	}
+	dryRun, err = command.Flags().GetBool("dry-run")
+	if err != nil {
+		panic(err)
+	}
+	recheck, err = command.Flags().GetBool("recheck")
+	if err != nil {
+		panic(err)
+	}
+	previousIndexFile, err = command.Flags().GetString("previous")
+	if err != nil {
+		panic(err)
+	}
+	selectedTags = make(map[string]bool)
+	for _, tag := range tags {
+		selectedTags[tag] = true
+	}
+
+	dataLock, err = lock.AcquireForConfig(config, command.Flags(), "sync")
+	if feedback.LogError(err) {
+		os.Exit(1)
+	}
+	defer dataLock.Release()
+
+	setup(config)
+	arduinoLintVersion, err = libraries.ArduinoLintVersion(config.ArduinoLintPath)
+	if err != nil {
+		arduinoLintVersion = "unknown"
+	}
+	rules = getRules()
+
+	syncLibraries(reposFile, selectedLibraries)
 }
 
-func syncLibraries(reposFile string) {
+func syncLibraries(reposFile string, selectedLibraries []string) {
 	if _, err := os.Stat(reposFile); os.IsNotExist(err) {
 		feedback.LogError(err)
-		os.Exit(1)
+		dataLock.Exit(1)
 	}
 
 	log.Println("Synchronizing libraries...")
+	report := newSyncReport()
 	repos, err := libraries.ListRepos(reposFile)
 	if feedback.LogError(err) {
-		os.Exit(1)
+		dataLock.Exit(1)
+	}
+	if len(selectedLibraries) > 0 {
+		selectiveSync = true
+		repos, err = selectRepos(repos, selectedLibraries)
+		if feedback.LogError(err) {
+			dataLock.Exit(1)
+		}
 	}
 
-	libraryDb := db.Init(config.LibrariesDB)
+	dbStorage, err := db.OpenStorage(config.LibrariesDBBackend, config.LibrariesDB)
+	if feedback.LogError(err) {
+		dataLock.Exit(1)
+	}
+	// A dry run must not save the changes recovered from the journal of an interrupted sync.
+	loadDb := db.InitWithStorage
+	if dryRun {
+		loadDb = db.InitReadOnly
+	}
+	libraryDb, err := loadDb(dbStorage)
+	if feedback.LogError(err) {
+		dataLock.Exit(1)
+	}
+	if !dryRun && (config.LibrariesDBCommitCount > 0 || config.LibrariesDBCommitInterval > 0) {
+		err := libraryDb.StartBatch(config.LibrariesDBCommitCount, time.Duration(config.LibrariesDBCommitInterval)*time.Second)
+		if feedback.LogError(err) {
+			dataLock.Exit(1)
+		}
+	}
+	closeDbOnSignal(libraryDb)
+	if config.SyncStateFile != "" {
+		syncState = syncstate.Init(config.SyncStateFile)
+	}
 
 	reposChan := make(chan *libraries.Repo)
 	go func() {
@@ -99,11 +198,13 @@ func syncLibraries(reposFile string) {
 			for repo := range reposChan {
 				buffer := &bytes.Buffer{}
 				logger := log.New(buffer, "", log.LstdFlags|log.LUTC)
-				syncLibrary(logger, repo, libraryDb)
+				report.addRepository(syncLibrary(logger, repo, libraryDb))
 
 				// Output log to file
-				if err := outputLogFile(repo, buffer); err != nil {
-					logger.Printf("Error writing log file: %s", err.Error())
+				if !dryRun {
+					if err := outputLogFile(repo, buffer); err != nil {
+						logger.Printf("Error writing log file: %s", err.Error())
+					}
 				}
 
 				// Output log to stdout
@@ -115,105 +216,445 @@ func syncLibraries(reposFile string) {
 	}
 	wg.Wait()
 
-	libraryIndex, err := libraryDb.OutputLibraryIndex()
-	if feedback.LogError(err) {
-		os.Exit(1)
+	if err := libraryDb.Close(); err != nil {
+		feedback.Errorf("While saving database: %s", err)
+		dataLock.Exit(1)
 	}
 
-	serializeLibraryIndex(libraryIndex, config.LibrariesIndex)
+	if dryRun {
+		fmt.Println("Dry run: no changes were made. Releases that would be added:")
+		for _, repoReport := range report.Repositories {
+			for _, tagReport := range repoReport.Tags {
+				if tagReport.Outcome == tagWouldIndex {
+					fmt.Printf("%s@%s (tag %s)\n", repoReport.LibraryName, tagReport.Version, tagReport.Tag)
+				}
+			}
+		}
+	} else {
+		if syncState != nil {
+			if err := syncState.SaveToFile(); err != nil {
+				feedback.Errorf("While saving sync state: %s", err)
+			}
+		}
+
+		libraryIndex, err := libraryDb.OutputLibraryIndex(db.IndexOptions{ReleaseDates: config.LibrariesIndexReleaseDates})
+		if feedback.LogError(err) {
+			dataLock.Exit(1)
+		}
+
+		serializeLibraryIndex(libraryIndex, config.LibrariesIndex, previousIndexFile)
+
+		report.UnresolvedDependencies = libraryDb.UnresolvedDependencies()
+		logUnresolvedDependencies(report.UnresolvedDependencies)
+
+		for _, output := range config.LibrariesIndexOutputs {
+			outputIndex, err := libraryDb.OutputLibraryIndex(db.IndexOptions{
+				ReleaseDates:  config.LibrariesIndexReleaseDates,
+				Types:         output.Types,
+				Architectures: output.Architectures,
+				LatestOnly:    output.LatestOnly,
+			})
+			if feedback.LogError(err) {
+				dataLock.Exit(1)
+			}
+
+			serializeLibraryIndex(outputIndex, output.Path, "")
+		}
+	}
+
+	if reportFile != "" {
+		report.LibrariesCount = len(libraryDb.Libraries)
+		report.ReleasesCount = len(libraryDb.Releases)
+		for _, release := range libraryDb.Releases {
+			if release.Indexable() {
+				report.IndexedReleasesCount++
+			}
+		}
+		if err := report.writeFile(reportFile); err != nil {
+			feedback.Errorf("While writing sync report: %s", err)
+			dataLock.Exit(1)
+		}
+	}
 
 	log.Println("...DONE")
 }
 
-func serializeLibraryIndex(libraryIndex interface{}, libraryIndexFile string) {
-	file, err := os.Create(libraryIndexFile)
-	if feedback.LogError(err) {
-		os.Exit(1)
+// getRules returns a string that identifies the rules applied to releases.
+func getRules() string {
+	return fmt.Sprintf("rules %s; arduino-lint %s; clamav %t", rulesVersion, arduinoLintVersion, !config.DoNotRunClamav)
+}
+
+// closeDbOnSignal saves the batched database changes and releases the data lock if the process is interrupted.
+func closeDbOnSignal(libraryDb *db.DB) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Println("Interrupted, saving database...")
+		if err := libraryDb.Close(); err != nil {
+			feedback.Errorf("While saving database: %s", err)
+		}
+		dataLock.Exit(1)
+	}()
+}
+
+// selectRepos returns the registry entries of the given library names.
+func selectRepos(repos []*libraries.Repo, libraryNames []string) ([]*libraries.Repo, error) {
+	reposByName := make(map[string]*libraries.Repo)
+	for _, repo := range repos {
+		reposByName[repo.LibraryName] = repo
+	}
+
+	var selected []*libraries.Repo
+	for _, libraryName := range libraryNames {
+		repo, found := reposByName[libraryName]
+		if !found {
+			return nil, fmt.Errorf("library %s not found in the registry", libraryName)
+		}
+		selected = append(selected, repo)
 	}
-	defer file.Close()
 
+	return selected, nil
+}
+
+// serializeLibraryIndex writes the index to the file. If compareWith is not empty, the changes compared to that index
+// file are logged.
+func serializeLibraryIndex(libraryIndex interface{}, libraryIndexFile string, compareWith string) {
 	b, err := json.MarshalIndent(libraryIndex, "", "  ")
 	if feedback.LogError(err) {
-		os.Exit(1)
+		dataLock.Exit(1)
+	}
+
+	if compareWith != "" {
+		logIndexChanges(compareWith, b)
 	}
 
-	_, err = file.Write(b)
+	err = index.Write(libraryIndexFile, b, index.WriteOptions{
+		MaxReleaseDrop: config.LibrariesIndexMaxReleaseDrop,
+		Compressions:   config.LibrariesIndexCompressions,
+		Checksums:      config.LibrariesIndexChecksums,
+		Signer:         indexSigner,
+	})
 	if feedback.LogError(err) {
-		os.Exit(1)
+		dataLock.Exit(1)
+	}
+}
+
+// logUnresolvedDependencies logs the dependencies of the indexed releases that can't be resolved, grouped by library.
+func logUnresolvedDependencies(unresolved map[string][]*db.UnresolvedDependency) {
+	libraryNames := []string{}
+	for libraryName := range unresolved {
+		libraryNames = append(libraryNames, libraryName)
+	}
+	sort.Strings(libraryNames)
+
+	for _, libraryName := range libraryNames {
+		log.Printf("Unresolved dependencies of %s:", libraryName)
+		for _, dependency := range unresolved[libraryName] {
+			log.Printf("  %s depends on %s: %s", dependency.Version, dependency.Dependency, dependency.Reason)
+		}
+	}
+}
+
+// logIndexChanges logs a summary of the differences between the previously published index and the new index data.
+func logIndexChanges(previousIndexFile string, data []byte) {
+	previousData, err := os.ReadFile(previousIndexFile)
+	if err != nil {
+		feedback.Warningf("While reading previous library index: %s", err)
+		return
+	}
+	changes, err := index.Compare(previousData, data)
+	if err != nil {
+		feedback.Warningf("While comparing with previous library index: %s", err)
+		return
+	}
+
+	log.Printf("Library index changes compared to %s: %d added, %d removed, %d changed", previousIndexFile, len(changes.Added), len(changes.Removed), len(changes.Changed))
+	for _, id := range changes.Added {
+		log.Printf("Added %s", id)
+	}
+	for _, id := range changes.Removed {
+		log.Printf("Removed %s", id)
+	}
+	for _, id := range changes.Changed {
+		log.Printf("Changed %s", id)
 	}
 }
 
 func setup(config *configuration.Config) {
 	err := os.MkdirAll(config.GitClonesFolder, os.FileMode(0777))
 	if feedback.LogError(err) {
-		os.Exit(1)
+		dataLock.Exit(1)
+	}
+	if dryRun {
+		return
 	}
 	err = os.MkdirAll(config.LibrariesFolder, os.FileMode(0777))
 	if feedback.LogError(err) {
-		os.Exit(1)
+		dataLock.Exit(1)
+	}
+	// Check the index configuration before the sync, so that an error doesn't waste it.
+	for _, format := range config.LibrariesIndexCompressions {
+		if feedback.LogError(index.CheckCompression(format)) {
+			dataLock.Exit(1)
+		}
+	}
+	if config.LibrariesIndexSigningKey != "" {
+		indexSigner, err = index.LoadSigner(config.LibrariesIndexSigningKey)
+		if feedback.LogError(err) {
+			dataLock.Exit(1)
+		}
 	}
 }
 
-func syncLibrary(logger *log.Logger, repoMetadata *libraries.Repo, libraryDb *db.DB) {
+func syncLibrary(logger *log.Logger, repoMetadata *libraries.Repo, libraryDb *db.DB) *repositoryReport {
+	startTime := time.Now()
+	repoReport := &repositoryReport{
+		URL:         repoMetadata.URL,
+		LibraryName: repoMetadata.LibraryName,
+		Outcome:     repositorySynced,
+		Tags:        []*tagReport{},
+	}
+	defer func() {
+		repoReport.Duration = time.Since(startTime).Seconds()
+	}()
+
 	logger.Printf("Scraping %s", repoMetadata.URL)
 
 	repoFolderName, err := repoMetadata.AsFolder()
 	if err != nil {
 		logger.Printf("Invalid URL: %s", err.Error())
-		return
+		repoReport.Outcome, repoReport.Reason = repositoryInvalidURL, err.Error()
+		return repoReport
 	}
 	repoFolder := filepath.Join(config.GitClonesFolder, repoFolderName)
 
+	// Explicitly selected libraries are always processed, as are all libraries when rechecking.
+	if syncState != nil && !selectiveSync && !recheck && unchanged(logger, repoMetadata, repoFolder) {
+		logger.Printf("Tags unchanged since last sync, skipping")
+		repoReport.Outcome = repositoryUnchanged
+		// Reproduce the logs of the library's releases, which would otherwise be lost from the log file.
+		if library, err := libraryDb.FindLibrary(repoMetadata.LibraryName); err == nil {
+			for _, release := range libraryDb.FindReleasesOfLibrary(library) {
+				if release.Log != "" {
+					logger.Print(release.Log)
+				}
+			}
+		}
+		return repoReport
+	}
+
 	// Clone repository
-	repo, err := libraries.CloneOrFetch(repoMetadata, repoFolder)
+	retryPolicy := libraries.FetchRetryPolicy{
+		Attempts: config.FetchAttempts,
+		Backoff:  time.Duration(config.FetchBackoff) * time.Second,
+	}
+	repo, err := libraries.CloneOrFetchWithRetry(repoMetadata, repoFolder, retryPolicy, logger)
 	if err != nil {
-		logger.Printf("Error fetching repository: %s", err)
-		logger.Printf("Removing clone and trying again")
-		os.RemoveAll(repoFolder)
-		repo, err = libraries.CloneOrFetch(repoMetadata, repoFolder)
-		if err != nil {
-			logger.Printf("Error fetching repository: %s", err)
-			logger.Printf("Leaving...")
-			return
+		logger.Printf("Leaving...")
+		repoReport.Outcome, repoReport.Reason = repositoryFetchError, err.Error()
+		repoReport.FetchFailures = 1
+		if syncState != nil {
+			repoReport.FetchFailures = syncState.RecordFetchFailure(repoMetadata.URL, repoMetadata.LibraryName, err)
+			if repoReport.FetchFailures > 1 {
+				logger.Printf("Repository failed to fetch on %d consecutive syncs", repoReport.FetchFailures)
+			}
 		}
+		return repoReport
+	}
+	if syncState != nil {
+		syncState.RecordFetchSuccess(repoMetadata.URL)
 	}
 
 	// Retrieve the list of git-tags
 	tags, err := gitutils.SortedCommitTags(repo.Repository)
 	if err != nil {
 		logger.Printf("Error retrieving git-tags: %s", err)
-		return
+		repoReport.Outcome, repoReport.Reason = repositoryTagsError, err.Error()
+		return repoReport
 	}
 
+	synced := true
+	foundTags := make(map[string]bool)
 	for _, tag := range tags {
+		if len(selectedTags) > 0 && !selectedTags[tag.Name().Short()] {
+			continue
+		}
+		foundTags[tag.Name().Short()] = true
+
+		commitHash, err := gitutils.TagCommitHash(repo.Repository, tag)
+		if err != nil {
+			logger.Printf("Error resolving tag %s: %s", tag.Name().Short(), err)
+		}
+
+		// Skip tags which were already rejected, unless they or the rules have changed since.
+		if !recheck && commitHash != "" {
+			rejected, _ := libraryDb.FindRejectedRelease(repoMetadata.LibraryName, tag.Name().Short())
+			if rejected != nil && rejected.CommitHash == commitHash && rejected.Rules == rules {
+				logger.Printf("Tag %s was previously rejected, skipping: %s", tag.Name().Short(), rejected.Reason)
+				if rejected.Output != "" {
+					logger.Print(rejected.Output)
+				}
+				repoReport.Tags = append(repoReport.Tags, &tagReport{
+					Tag:     tag.Name().Short(),
+					Outcome: tagPreviouslyRejected,
+					Reason:  rejected.Reason,
+				})
+				continue
+			}
+		}
+
 		// Sync the library release for each git-tag
-		err = syncLibraryTaggedRelease(logger, repo, tag, repoMetadata, libraryDb)
+		tagStartTime := time.Now()
+		tagReport, err := syncLibraryTaggedRelease(logger, repo, tag, repoMetadata, libraryDb)
+		tagReport.Tag = tag.Name().Short()
+		tagReport.Duration = time.Since(tagStartTime).Seconds()
+		if !recordTagOutcome(logger, libraryDb, repoMetadata, tagReport, err, commitHash) {
+			synced = false
+		}
+		repoReport.Tags = append(repoReport.Tags, tagReport)
+	}
+
+	for tag := range selectedTags {
+		if !foundTags[tag] {
+			logger.Printf("Tag %s not found", tag)
+		}
+	}
+
+	// Tags which failed to sync must be processed again on the next run, so the state is only updated on full success.
+	if syncState != nil && synced && len(selectedTags) == 0 {
+		tagHashes, err := gitutils.TagHashes(repo.Repository)
+		if err != nil {
+			logger.Printf("Error retrieving git-tags: %s", err)
+			return repoReport
+		}
+		syncState.Update(repoMetadata.URL, repoMetadata.LibraryName, rules, tagHashes)
+	}
+
+	return repoReport
+}
+
+// recordTagOutcome records the outcome of the sync of the tag, which failed if syncErr is not nil, and returns whether
+// the tag doesn't need to be processed again by the next sync.
+func recordTagOutcome(logger *log.Logger, libraryDb *db.DB, repoMetadata *libraries.Repo, tagReport *tagReport, syncErr error, commitHash string) bool {
+	synced := true
+	if syncErr != nil {
+		logger.Printf("Error syncing library: %s", syncErr)
+		tagReport.Reason = syncErr.Error()
+		// Rejections will occur again until the tag or the rules change, so only other failures must be retried by the
+		// next sync.
+		if !rejectedOutcome(tagReport.Outcome) || commitHash == "" {
+			synced = false
+		}
+	}
+
+	if !dryRun && commitHash != "" {
+		if err := updateRejectedRelease(libraryDb, repoMetadata, tagReport, commitHash); err != nil {
+			logger.Printf("Error updating rejected releases: %s", err)
+			synced = false
+		}
+	}
+	return synced
+}
+
+// updateRejectedRelease records the tag in the database's rejected releases if it failed the requirements for addition
+// to the index, or removes it from them if it was accepted.
+func updateRejectedRelease(libraryDb *db.DB, repoMetadata *libraries.Repo, tagReport *tagReport, commitHash string) error {
+	switch {
+	case rejectedOutcome(tagReport.Outcome):
+		err := libraryDb.AddRejectedRelease(&db.RejectedRelease{
+			LibraryName: repoMetadata.LibraryName,
+			Tag:         tagReport.Tag,
+			CommitHash:  commitHash,
+			Rules:       rules,
+			Reason:      tagReport.Reason,
+			Output:      tagReport.output,
+			Time:        time.Now().UTC(),
+		})
 		if err != nil {
-			logger.Printf("Error syncing library: %s", err)
+			return err
 		}
+	case tagReport.Outcome == tagIndexed || tagReport.Outcome == tagAlreadyLoaded:
+		if rejected, _ := libraryDb.FindRejectedRelease(repoMetadata.LibraryName, tagReport.Tag); rejected == nil {
+			return nil
+		}
+		if err := libraryDb.RemoveRejectedRelease(repoMetadata.LibraryName, tagReport.Tag); err != nil {
+			return err
+		}
+	default:
+		return nil
 	}
+
+	return libraryDb.Commit()
 }
 
-func syncLibraryTaggedRelease(logger *log.Logger, repo *libraries.Repository, tag *plumbing.Reference, repoMeta *libraries.Repo, libraryDb *db.DB) error {
+// rejectedOutcome returns whether the outcome of the sync of a tag is a failure caused by its content, as opposed to
+// the I/O errors and failures to run the check tools, which may not occur again.
+func rejectedOutcome(outcome string) bool {
+	switch outcome {
+	case tagMetadataError, tagWrongName, tagInvalidMetadata, tagAntivirusFailure, tagLintFailure:
+		return true
+	}
+	return false
+}
+
+// checkOutcome returns the outcome of the tag for the error of a check tool: failureOutcome if the tool found problems
+// with the release, errorOutcome if it could not check it.
+func checkOutcome(err error, failureOutcome string, errorOutcome string) string {
+	if libraries.IsFindingsError(err) {
+		return failureOutcome
+	}
+	return errorOutcome
+}
+
+// unchanged returns whether the repository has a local clone and its remote tags and the rules are the same as at the
+// last successful sync.
+func unchanged(logger *log.Logger, repoMetadata *libraries.Repo, repoFolder string) bool {
+	if _, err := os.Stat(repoFolder); err != nil {
+		return false
+	}
+
+	tagHashes, err := gitutils.RemoteTagHashes(repoMetadata.URL)
+	if err != nil {
+		// The error will be handled by the fetch.
+		logger.Printf("Error listing remote git-tags: %s", err)
+		return false
+	}
+
+	return syncState.Unchanged(repoMetadata.URL, repoMetadata.LibraryName, rules, tagHashes)
+}
+
+// syncLibraryTaggedRelease syncs the release of the given tag and returns the report of the outcome. The reason for
+// failures is the returned error.
+func syncLibraryTaggedRelease(logger *log.Logger, repo *libraries.Repository, tag *plumbing.Reference, repoMeta *libraries.Repo, libraryDb *db.DB) (*tagReport, error) {
 	var releaseLog string // This string will be displayed in the logs for indexed releases.
 
 	// Checkout desired tag
 	logger.Printf("Checking out tag: %s", tag.Name().Short())
 	if err := gitutils.CheckoutTag(repo.Repository, tag); err != nil {
-		return fmt.Errorf("error checking out repo: %s", err)
+		return &tagReport{Outcome: tagCheckoutError}, fmt.Errorf("error checking out repo: %s", err)
 	}
 
 	// Create library metadata from library.properties
-	library, err := libraries.GenerateLibraryFromRepo(repo)
+	library, findings, err := libraries.GenerateLibraryFromRepo(repo)
 	if err != nil {
-		return fmt.Errorf("error generating library from repo: %s", err)
+		outcome := tagMetadataError
+		// A missing library.properties is a problem of the release, but other errors reading it may not occur again.
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) && !errors.Is(err, fs.ErrNotExist) {
+			outcome = tagMetadataReadError
+		}
+		return &tagReport{Outcome: outcome}, fmt.Errorf("error generating library from repo: %s", err)
 	}
 	library.Types = repoMeta.Types
+	report := &tagReport{Version: library.Version, Findings: findings}
 
 	// If the release name is different from the listed name, skip release...
 	if library.Name != repoMeta.LibraryName {
 		logger.Printf("Release %s:%s has wrong library name, should be %s", library.Name, library.Version, repoMeta.LibraryName)
-		return nil
+		report.Outcome = tagWrongName
+		report.Reason = fmt.Sprintf("library name %s does not match registered name %s", library.Name, repoMeta.LibraryName)
+		return report, nil
 	}
 
 	releaseQuery := db.Release{
@@ -227,18 +668,36 @@ func syncLibraryTaggedRelease(logger *log.Logger, repo *libraries.Repository, ta
 			if release.Log != "" {
 				logger.Print(release.Log)
 			}
-			return nil
+			report.Outcome = tagAlreadyLoaded
+			return report, nil
+		}
+	}
+
+	if len(findings) > 0 {
+		findingsLog := "library.properties validation found problems:\n"
+		for _, finding := range findings {
+			findingsLog += finding.String() + "\n"
+		}
+		logger.Print(findingsLog)
+		releaseLog += findingsLog
+		if metadata.HasErrors(findings) {
+			report.Outcome = tagInvalidMetadata
+			report.Reason = "invalid library.properties metadata"
+			report.output = findingsLog
+			return report, nil
 		}
 	}
 
 	if !config.DoNotRunClamav {
 		if out, err := libraries.RunAntiVirus(repo.FolderPath); err != nil {
 			logger.Printf("clamav output:\n%s", out)
-			return err
+			report.Outcome = checkOutcome(err, tagAntivirusFailure, tagAntivirusError)
+			report.output = string(out)
+			return report, err
 		}
 	}
 
-	report, err := libraries.RunArduinoLint(config.ArduinoLintPath, repo.FolderPath, repoMeta)
+	lintReport, err := libraries.RunArduinoLint(config.ArduinoLintPath, repo.FolderPath, repoMeta)
 	reportTemplate := `<a href="https://arduino.github.io/arduino-lint/latest/">Arduino Lint</a> %s:
 <details><summary>Click to expand Arduino Lint report</summary>
 <hr>
@@ -246,35 +705,63 @@ func syncLibraryTaggedRelease(logger *log.Logger, repo *libraries.Repository, ta
 <hr>
 </details>`
 	if err != nil {
-		logger.Printf(reportTemplate, "found errors", report)
-		return err
+		logger.Printf(reportTemplate, "found errors", lintReport)
+		report.Outcome = checkOutcome(err, tagLintFailure, tagLintError)
+		report.output = fmt.Sprintf(reportTemplate, "found errors", lintReport)
+		return report, err
 	}
-	if report != nil {
-		formattedReport := fmt.Sprintf(reportTemplate, "has suggestions for possible improvements", report)
+	if lintReport != nil {
+		formattedReport := fmt.Sprintf(reportTemplate, "has suggestions for possible improvements", lintReport)
 		logger.Print(formattedReport)
 		releaseLog += formattedReport
 	}
 
+	if dryRun {
+		logger.Printf("Release %s:%s would be added", library.Name, library.Version)
+		report.Outcome = tagWouldIndex
+		return report, nil
+	}
+
 	archiveData, err := archive.New(repo, library, config)
 	if err != nil {
-		return fmt.Errorf("error while configuring library release archive: %s", err)
+		report.Outcome = tagArchiveError
+		return report, fmt.Errorf("error while configuring library release archive: %s", err)
 	}
 	if err := archiveData.Create(); err != nil {
-		return fmt.Errorf("error while zipping library: %s", err)
+		report.Outcome = tagArchiveError
+		return report, fmt.Errorf("error while zipping library: %s", err)
 	}
 
-	release := db.FromLibraryToRelease(library)
+	release, dependencyErrors := db.FromLibraryToRelease(library)
+	for _, err := range dependencyErrors {
+		message := fmt.Sprintf("Ignored invalid entry of library.properties depends field: %s", err)
+		logger.Print(message)
+		releaseLog += message + "\n"
+	}
 	release.URL = archiveData.URL
 	release.ArchiveFileName = archiveData.FileName
 	release.Size = archiveData.Size
 	release.Checksum = archiveData.Checksum
 	release.Log = releaseLog
+	release.Tag = tag.Name().Short()
+	if provenance, err := gitutils.GetTagProvenance(repo.Repository, tag); err != nil {
+		logger.Printf("Error resolving provenance of tag %s: %s", tag.Name().Short(), err)
+	} else {
+		release.CommitHash = provenance.CommitHash
+		release.CommitDate = provenance.CommitDate
+		release.TagDate = provenance.TagDate
+	}
+	release.IndexTime = time.Now().UTC()
+	release.EngineVersion = version.Get()
+	release.ArduinoLintVersion = arduinoLintVersion
 
 	if err := libraries.UpdateLibrary(release, repo.URL, libraryDb); err != nil {
-		return fmt.Errorf("error while updating library DB: %s", err)
+		report.Outcome = tagDatabaseError
+		return report, fmt.Errorf("error while updating library DB: %s", err)
 	}
 
-	return nil
+	report.Outcome = tagIndexed
+	return report, nil
 }
 
 func outputLogFile(repoMetadata *libraries.Repo, buffer *bytes.Buffer) error {
@@ -0,0 +1,102 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sync
+
+import (
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/libraries-repository-engine/internal/libraries"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectRepos(t *testing.T) {
+	repos := []*libraries.Repo{
+		{URL: "https://github.com/Bar/FooLib.git", LibraryName: "FooLib"},
+		{URL: "https://github.com/Bar/BazLib.git", LibraryName: "BazLib"},
+		{URL: "https://github.com/Zeb/QuxLib.git", LibraryName: "QuxLib"},
+	}
+
+	selected, err := selectRepos(repos, []string{"QuxLib", "FooLib"})
+	require.NoError(t, err)
+	assert.Equal(t, []*libraries.Repo{repos[2], repos[0]}, selected)
+
+	_, err = selectRepos(repos, []string{"FooLib", "nonexistent"})
+	assert.Error(t, err)
+}
+
+func TestUpdateRejectedRelease(t *testing.T) {
+	dbFolder, err := paths.MkTempDir("", "sync-TestUpdateRejectedRelease")
+	require.NoError(t, err)
+	defer dbFolder.RemoveAll()
+	libraryDb := db.New(dbFolder.Join("db.json").String())
+	repoMetadata := &libraries.Repo{URL: "https://github.com/Bar/FooLib.git", LibraryName: "FooLib"}
+
+	for _, outcome := range []string{tagMetadataError, tagWrongName, tagInvalidMetadata, tagAntivirusFailure, tagLintFailure} {
+		require.NoError(t, updateRejectedRelease(libraryDb, repoMetadata, &tagReport{Tag: "1.0.0", Outcome: outcome}, "abc"))
+		rejected, err := libraryDb.FindRejectedRelease("FooLib", "1.0.0")
+		require.NoError(t, err, outcome)
+		assert.Equal(t, "abc", rejected.CommitHash)
+
+		require.NoError(t, updateRejectedRelease(libraryDb, repoMetadata, &tagReport{Tag: "1.0.0", Outcome: tagIndexed}, "abc"))
+		_, err = libraryDb.FindRejectedRelease("FooLib", "1.0.0")
+		assert.Error(t, err, outcome)
+	}
+
+	// Failures which may not occur again are not recorded.
+	for _, outcome := range []string{tagCheckoutError, tagMetadataReadError, tagAntivirusError, tagLintError, tagArchiveError, tagDatabaseError} {
+		require.NoError(t, updateRejectedRelease(libraryDb, repoMetadata, &tagReport{Tag: "1.0.0", Outcome: outcome}, "abc"))
+		_, err := libraryDb.FindRejectedRelease("FooLib", "1.0.0")
+		assert.Error(t, err, outcome)
+	}
+}
+
+func TestRecordTagOutcomeMissingTool(t *testing.T) {
+	dbFolder, err := paths.MkTempDir("", "sync-TestRecordTagOutcomeMissingTool")
+	require.NoError(t, err)
+	defer dbFolder.RemoveAll()
+	libraryDb := db.New(dbFolder.Join("db.json").String())
+	repoMetadata := &libraries.Repo{URL: "https://github.com/Bar/FooLib.git", LibraryName: "FooLib"}
+	logger := log.New(io.Discard, "", 0)
+
+	_, lintErr := libraries.RunArduinoLint(dbFolder.Join("arduino-lint").String(), dbFolder.String(), repoMetadata)
+	require.Error(t, lintErr)
+	report := &tagReport{Tag: "1.0.0", Outcome: checkOutcome(lintErr, tagLintFailure, tagLintError)}
+	assert.Equal(t, tagLintError, report.Outcome)
+
+	assert.False(t, recordTagOutcome(logger, libraryDb, repoMetadata, report, lintErr, "abc"), "Sync state must not be updated")
+	_, err = libraryDb.FindRejectedRelease("FooLib", "1.0.0")
+	assert.Error(t, err, "Release must not be rejected")
+
+	// A tool which ran and found problems rejects the release.
+	report = &tagReport{Tag: "1.0.0", Outcome: tagLintFailure}
+	assert.True(t, recordTagOutcome(logger, libraryDb, repoMetadata, report, errors.New("exit status 1"), "abc"))
+	_, err = libraryDb.FindRejectedRelease("FooLib", "1.0.0")
+	assert.NoError(t, err)
+}
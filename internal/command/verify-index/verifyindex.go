@@ -0,0 +1,62 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package verifyindex implements the `verify-index` CLI subcommand used to check the signature of a library index.
+package verifyindex
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/index"
+	"github.com/spf13/cobra"
+)
+
+// Run executes the command.
+func Run(command *cobra.Command, cliArguments []string) {
+	indexPath := cliArguments[0]
+	publicKeyPath := cliArguments[1]
+	signaturePath := indexPath + index.SignatureSuffix
+	if len(cliArguments) > 2 {
+		signaturePath = cliArguments[2]
+	}
+
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		feedback.Errorf("While reading library index: %s", err)
+		os.Exit(1)
+	}
+	signature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		feedback.Errorf("While reading signature: %s", err)
+		os.Exit(1)
+	}
+
+	if err := index.VerifySignature(data, signature, publicKeyPath); err != nil {
+		feedback.Errorf("Verification of %s failed: %s", indexPath, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Signature of %s is valid\n", indexPath)
+}
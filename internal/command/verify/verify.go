@@ -0,0 +1,153 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package verify implements the `verify` CLI subcommand used to check the release archives against the libraries
+// database.
+package verify
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/arduino/libraries-repository-engine/internal/libraries"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/archive"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
+	"github.com/arduino/libraries-repository-engine/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// report is the result of the verification.
+type report struct {
+	Releases int              `json:"releases"` // Number of releases verified.
+	Problems []*releaseReport `json:"problems"`
+}
+
+// releaseReport lists the problems found with a release.
+type releaseReport struct {
+	Library  string   `json:"library"`
+	Version  string   `json:"version"`
+	Archive  string   `json:"archive,omitempty"`
+	Problems []string `json:"problems"`
+}
+
+// Run executes the command.
+func Run(command *cobra.Command, cliArguments []string) {
+	config := configuration.ReadConf(command.Flags())
+
+	format := output.GetFormat(command.Flags(), output.TextFormat, output.JSONFormat)
+	fail, err := command.Flags().GetBool("fail")
+	if err != nil {
+		panic(err)
+	}
+
+	librariesDb, err := db.LoadExisting(config.LibrariesDBBackend, config.LibrariesDB)
+	if err != nil {
+		feedback.Errorf("While loading database: %s", err)
+		os.Exit(1)
+	}
+	result := verifyReleases(librariesDb, config)
+
+	if format == output.JSONFormat {
+		output.PrintJSON(result)
+	} else {
+		printText(os.Stdout, result)
+	}
+
+	if fail && len(result.Problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// verifyReleases checks the archive of every release in the database.
+func verifyReleases(librariesDb *db.DB, config *configuration.Config) *report {
+	result := report{Problems: []*releaseReport{}}
+	for _, release := range librariesDb.Releases {
+		result.Releases++
+		if releaseResult := verifyRelease(librariesDb, release, config); len(releaseResult.Problems) > 0 {
+			result.Problems = append(result.Problems, releaseResult)
+		}
+	}
+	return &result
+}
+
+// verifyRelease checks the archive of the release against the data stored in the database.
+func verifyRelease(librariesDb *db.DB, release *db.Release, config *configuration.Config) *releaseReport {
+	result := releaseReport{
+		Library:  release.LibraryName,
+		Version:  release.Version.String(),
+		Problems: []string{},
+	}
+	addProblem := func(format string, a ...interface{}) {
+		result.Problems = append(result.Problems, fmt.Sprintf(format, a...))
+	}
+
+	library, err := librariesDb.FindLibrary(release.LibraryName)
+	if err != nil {
+		addProblem("library not found in database")
+		return &result
+	}
+
+	repository := libraries.Repository{URL: library.Repository}
+	libraryMetadata := metadata.LibraryMetadata{
+		Name:    library.Name,
+		Version: release.Version.String(),
+	}
+	archiveData, err := archive.New(&repository, &libraryMetadata, config)
+	if err != nil {
+		addProblem("invalid repository URL %s: %s", library.Repository, err)
+		return &result
+	}
+	result.Archive = archiveData.Path
+
+	if release.URL != archiveData.URL {
+		addProblem("URL %s does not match expected URL %s", release.URL, archiveData.URL)
+	}
+	if release.ArchiveFileName != archiveData.FileName {
+		addProblem("archive file name %s does not match expected name %s", release.ArchiveFileName, archiveData.FileName)
+	}
+	if release.Size == 0 || release.Checksum == "" {
+		addProblem("size or checksum missing from database")
+	}
+	for _, problem := range archiveData.Verify(release.Size, release.Checksum) {
+		addProblem("%s", problem)
+	}
+
+	return &result
+}
+
+// printText writes the report in human readable format.
+func printText(output io.Writer, result *report) {
+	problemCount := 0
+	for _, releaseResult := range result.Problems {
+		fmt.Fprintf(output, "%s@%s (%s):\n", releaseResult.Library, releaseResult.Version, releaseResult.Archive)
+		for _, problem := range releaseResult.Problems {
+			fmt.Fprintf(output, "  %s\n", problem)
+		}
+		problemCount += len(releaseResult.Problems)
+	}
+	fmt.Fprintf(output, "Verified %d releases: %d problems in %d releases\n", result.Releases, problemCount, len(result.Problems))
+}
@@ -0,0 +1,99 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package verify
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/libraries"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/archive"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/db"
+	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyReleases(t *testing.T) {
+	config := &configuration.Config{
+		LibrariesFolder: t.TempDir(),
+		BaseDownloadURL: "https://downloads.example.com/libraries/",
+	}
+	sourcePath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(sourcePath, "library.properties"), []byte("name=FooLib\n"), 0644))
+
+	librariesDb := db.New(filepath.Join(t.TempDir(), "db.json"))
+	repositoryURL := "https://github.com/Bar/FooLib.git"
+	require.NoError(t, librariesDb.AddLibrary(&db.Library{Name: "FooLib", Repository: repositoryURL}))
+
+	addRelease := func(version string) *db.Release {
+		archiveData, err := archive.New(
+			&libraries.Repository{URL: repositoryURL, FolderPath: sourcePath},
+			&metadata.LibraryMetadata{Name: "FooLib", Version: version},
+			config,
+		)
+		require.NoError(t, err)
+		require.NoError(t, archiveData.Create())
+		release := &db.Release{
+			LibraryName:     "FooLib",
+			Version:         db.VersionFromString(version),
+			URL:             archiveData.URL,
+			ArchiveFileName: archiveData.FileName,
+			Size:            archiveData.Size,
+			Checksum:        archiveData.Checksum,
+		}
+		require.NoError(t, librariesDb.AddRelease(release, repositoryURL))
+		return release
+	}
+	addRelease("1.0.0")
+	wrongChecksum := addRelease("1.1.0")
+	wrongChecksum.Checksum = "SHA-256:0"
+	wrongURL := addRelease("1.2.0")
+	wrongURL.URL = "https://downloads.example.com/libraries/github.com/Baz/FooLib-1.2.0.zip"
+	missing := addRelease("1.3.0")
+	require.NoError(t, os.Remove(filepath.Join(config.LibrariesFolder, "github.com", "Bar", "FooLib-1.3.0.zip")))
+	unrecorded := addRelease("1.4.0")
+	unrecorded.Size = 0
+	unrecorded.Checksum = ""
+
+	result := verifyReleases(librariesDb, config)
+	assert.Equal(t, 5, result.Releases)
+	require.Len(t, result.Problems, 4)
+	assert.Equal(t, wrongChecksum.Version.String(), result.Problems[0].Version)
+	assert.Len(t, result.Problems[0].Problems, 1)
+	assert.Equal(t, wrongURL.Version.String(), result.Problems[1].Version)
+	assert.Len(t, result.Problems[1].Problems, 1)
+	assert.Equal(t, missing.Version.String(), result.Problems[2].Version)
+	assert.Len(t, result.Problems[2].Problems, 1)
+	assert.Equal(t, unrecorded.Version.String(), result.Problems[3].Version)
+	assert.Equal(t, []string{"size or checksum missing from database"}, result.Problems[3].Problems)
+
+	var output bytes.Buffer
+	printText(&output, result)
+	assert.Contains(t, output.String(), "FooLib@1.1.0 (")
+	assert.Contains(t, output.String(), "Verified 5 releases: 4 problems in 4 releases\n")
+}
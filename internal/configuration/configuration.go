@@ -42,6 +42,47 @@ type Config struct {
 	GitClonesFolder string
 	DoNotRunClamav  bool
 	ArduinoLintPath string
+
+	// Repositories whose tags are unchanged since the last sync are skipped. Disabled if empty.
+	SyncStateFile string
+	// Maximum fraction by which the index release count may drop compared to the previous index. Disabled if zero.
+	LibrariesIndexMaxReleaseDrop float64
+	// Add the commit date and indexing date of the releases to the index.
+	LibrariesIndexReleaseDates bool
+	// Compressed variants of the index to write alongside it: "gz" and/or "bz2" (requires the bzip2 tool).
+	LibrariesIndexCompressions []string
+	// Write a manifest of the SHA-256 checksums of the index files, with the ".sha256" suffix.
+	LibrariesIndexChecksums bool
+	// Additional indexes, each containing a subset of the releases of the main index.
+	LibrariesIndexOutputs []IndexOutput
+	// Private key used to write a detached signature of the index, with the ".sig" suffix: an unencrypted OpenPGP key or
+	// an Ed25519 key in PKCS #8 PEM format. Disabled if empty.
+	LibrariesIndexSigningKey string
+	// During sync, the database file is saved after this number of changes. Changes are saved individually if both
+	// LibrariesDBCommitCount and LibrariesDBCommitInterval are zero.
+	LibrariesDBCommitCount int
+	// During sync, the database file is saved after this number of seconds since the last save.
+	LibrariesDBCommitInterval int
+	// Maximum number of attempts to fetch a repository when the failure is caused by a transient error.
+	FetchAttempts int
+	// Seconds to wait before the first retry of a failed fetch, doubled for each subsequent retry.
+	FetchBackoff int
+	// Storage backend of LibrariesDB: "json" (the default) or "bolt".
+	LibrariesDBBackend string
+	// Lock file preventing concurrent modification of the data by multiple commands. Defaults to LibrariesDB with the
+	// ".lock" suffix.
+	LockFile string
+	// Log of the administrative operations on the data. Defaults to LibrariesDB with the ".audit.jsonl" suffix.
+	AuditLogFile string
+}
+
+// IndexOutput is the configuration of an additional index. The compression, checksums and signing configuration of
+// the main index also applies to it.
+type IndexOutput struct {
+	Path          string
+	Types         []string // Only releases having one of these types (e.g., "Arduino"). All releases if empty.
+	Architectures []string // Only releases compatible with one of these architectures. All releases if empty.
+	LatestOnly    bool     // Only the latest of the selected releases of each library.
 }
 
 // ReadConf reads the configuration file and returns the data.
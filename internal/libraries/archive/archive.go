@@ -25,16 +25,20 @@
 package archive
 
 import (
+	"archive/zip"
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/arduino/libraries-repository-engine/internal/configuration"
 	"github.com/arduino/libraries-repository-engine/internal/libraries"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/hash"
 	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
-	"github.com/arduino/libraries-repository-engine/internal/libraries/zip"
+	ziphelper "github.com/arduino/libraries-repository-engine/internal/libraries/zip"
 )
 
 // Archive is the type for library release archive data.
@@ -79,7 +83,7 @@ func (archive *Archive) Create() error {
 		return err
 	}
 
-	if err := zip.Directory(archive.SourcePath, archive.RootName, archive.Path); err != nil {
+	if err := ziphelper.Directory(archive.SourcePath, archive.RootName, archive.Path); err != nil {
 		os.Remove(archive.Path)
 		return err
 	}
@@ -94,6 +98,40 @@ func (archive *Archive) Create() error {
 	return nil
 }
 
+// Verify checks that the archive file exists, has the given size and checksum, and contains a single root folder of
+// the expected name. It returns the problems found. A zero size or an empty checksum is not compared.
+func (archive *Archive) Verify(size int64, checksum string) []error {
+	actualSize, actualChecksum, err := getSizeAndCalculateChecksum(archive.Path)
+	if err != nil {
+		return []error{err}
+	}
+
+	var problems []error
+	if size != 0 && actualSize != size {
+		problems = append(problems, fmt.Errorf("size %d does not match stored size %d", actualSize, size))
+	}
+	if checksum != "" && actualChecksum != checksum {
+		problems = append(problems, fmt.Errorf("checksum %s does not match stored checksum %s", actualChecksum, checksum))
+	}
+
+	reader, err := zip.OpenReader(archive.Path)
+	if err != nil {
+		return append(problems, fmt.Errorf("invalid zip file: %s", err))
+	}
+	defer reader.Close()
+	if len(reader.File) == 0 {
+		problems = append(problems, errors.New("empty zip file"))
+	}
+	for _, file := range reader.File {
+		if rootName := strings.SplitN(file.Name, "/", 2)[0]; rootName != archive.RootName {
+			problems = append(problems, fmt.Errorf("file %s is not under root folder %s", file.Name, archive.RootName))
+			break
+		}
+	}
+
+	return problems
+}
+
 var zipFolderNamePattern = regexp.MustCompile("[^a-zA-Z0-9]")
 
 // zipFolderName returns the name to use for the folder.
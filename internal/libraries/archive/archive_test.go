@@ -86,3 +86,30 @@ func TestCreate(t *testing.T) {
 	assert.Greater(t, archiveObject.Size, int64(0))
 	assert.NotEmpty(t, archiveObject.Checksum)
 }
+
+func TestVerify(t *testing.T) {
+	archiveDir := filepath.Join(os.TempDir(), "TestVerifyArchiveDir")
+	defer os.RemoveAll(archiveDir)
+	archivePath := filepath.Join(archiveDir, "TestVerifyArchive.zip")
+
+	archiveObject := Archive{
+		Path:       archivePath,
+		SourcePath: filepath.Join(testDataPath, "gitclones", "SomeRepository"),
+		RootName:   "SomeLibrary",
+	}
+	require.NoError(t, archiveObject.Create(), "This test must be run as administrator on Windows to have symlink creation privilege.")
+
+	assert.Empty(t, archiveObject.Verify(archiveObject.Size, archiveObject.Checksum))
+	assert.Len(t, archiveObject.Verify(archiveObject.Size+1, "SHA-256:0"), 2, "Wrong size and checksum")
+	assert.Empty(t, archiveObject.Verify(0, ""), "Unknown size and checksum")
+
+	wrongRootName := archiveObject
+	wrongRootName.RootName = "OtherLibrary"
+	assert.Len(t, wrongRootName.Verify(archiveObject.Size, archiveObject.Checksum), 1, "Wrong root folder")
+
+	require.NoError(t, os.WriteFile(archivePath, []byte("not a zip file"), 0644))
+	assert.Len(t, archiveObject.Verify(archiveObject.Size, archiveObject.Checksum), 3, "Corrupted archive")
+
+	require.NoError(t, os.Remove(archivePath))
+	assert.Len(t, archiveObject.Verify(archiveObject.Size, archiveObject.Checksum), 1, "Missing archive")
+}
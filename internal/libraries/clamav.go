@@ -25,19 +25,27 @@ package libraries
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 )
 
-// RunAntiVirus scans the folder for viruses.
+// clamdscanVirusFoundExitCode is the exit code of clamdscan when it found infected files.
+const clamdscanVirusFoundExitCode = 1
+
+// RunAntiVirus scans the folder for viruses. If infected files were found, the error is a *FindingsError.
 func RunAntiVirus(folder string) ([]byte, error) {
 	cmd := exec.Command("clamdscan", "--fdpass", "-i", folder)
 	cmd.Env = append(os.Environ(), "LANG=en")
 
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return out, err
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == clamdscanVirusFoundExitCode {
+			return out, &FindingsError{err: exitErr}
+		}
+		return out, fmt.Errorf("error running clamdscan: %w", err)
 	}
 
 	output := string(out)
@@ -47,3 +55,23 @@ func RunAntiVirus(folder string) ([]byte, error) {
 
 	return out, nil
 }
+
+// FindingsError is returned by the checks of a library release when the check tool ran and found problems with the
+// release, as opposed to the errors which prevented the tool from checking it.
+type FindingsError struct {
+	err *exec.ExitError
+}
+
+func (err *FindingsError) Error() string {
+	return err.err.Error()
+}
+
+func (err *FindingsError) Unwrap() error {
+	return err.err
+}
+
+// IsFindingsError returns whether the error, or one it wraps, is a *FindingsError.
+func IsFindingsError(err error) bool {
+	var findingsErr *FindingsError
+	return errors.As(err, &findingsErr)
+}
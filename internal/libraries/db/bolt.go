@@ -0,0 +1,206 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets of the Bolt storage. Each record is stored as a JSON value under a key identifying it.
+var (
+	metadataBucket         = []byte("metadata")
+	librariesBucket        = []byte("libraries")
+	releasesBucket         = []byte("releases")
+	rejectedReleasesBucket = []byte("rejectedReleases")
+
+	schemaVersionKey = []byte("schemaVersion")
+)
+
+// BoltStorage stores the database in a Bolt embedded key-value store. Each save is a single transaction which only
+// writes the records that changed, so an interrupted save leaves the store unchanged.
+//
+// Records are loaded in key order, so the order of the libraries and releases of the database is not preserved.
+type BoltStorage struct {
+	path string
+}
+
+// openTimeout is how long to wait for the lock of a Bolt store held by another process.
+const openTimeout = 10 * time.Second
+
+// NewBoltStorage returns a storage using the Bolt store at the given path, which is created by the first save if it
+// doesn't exist. The store is only open during loads and saves, so that other processes can read it in the meantime.
+func NewBoltStorage(path string) *BoltStorage {
+	return &BoltStorage{path: path}
+}
+
+// recordKey returns the key of a record identified by the given fields.
+func recordKey(fields ...string) []byte {
+	// Null is not valid in library names, versions or tags.
+	return []byte(strings.Join(fields, "\x00"))
+}
+
+// Load reads the database from the Bolt store.
+func (storage *BoltStorage) Load(db *DB) error {
+	if _, err := os.Stat(storage.path); err != nil {
+		return err // A read-only store can't be created.
+	}
+	boltDB, err := bolt.Open(storage.path, 0644, &bolt.Options{ReadOnly: true, Timeout: openTimeout})
+	if err != nil {
+		return err
+	}
+	defer boltDB.Close()
+
+	return boltDB.View(func(tx *bolt.Tx) error {
+		db.SchemaVersion = 0
+		if bucket := tx.Bucket(metadataBucket); bucket != nil {
+			if value := bucket.Get(schemaVersionKey); value != nil {
+				if err := json.Unmarshal(value, &db.SchemaVersion); err != nil {
+					return err
+				}
+			}
+		}
+		db.Libraries = nil
+		if err := loadBucket(tx, librariesBucket, func(value []byte) error {
+			library := new(Library)
+			db.Libraries = append(db.Libraries, library)
+			return json.Unmarshal(value, library)
+		}); err != nil {
+			return err
+		}
+		db.Releases = nil
+		if err := loadBucket(tx, releasesBucket, func(value []byte) error {
+			release := new(Release)
+			db.Releases = append(db.Releases, release)
+			return json.Unmarshal(value, release)
+		}); err != nil {
+			return err
+		}
+		db.RejectedReleases = nil
+		return loadBucket(tx, rejectedReleasesBucket, func(value []byte) error {
+			rejected := new(RejectedRelease)
+			db.RejectedReleases = append(db.RejectedReleases, rejected)
+			return json.Unmarshal(value, rejected)
+		})
+	})
+}
+
+func loadBucket(tx *bolt.Tx, name []byte, load func(value []byte) error) error {
+	bucket := tx.Bucket(name)
+	if bucket == nil {
+		return nil // Nothing was saved yet.
+	}
+	return bucket.ForEach(func(key []byte, value []byte) error {
+		return load(value)
+	})
+}
+
+// Save writes the changed records of the database to the Bolt store and deletes those no longer present.
+func (storage *BoltStorage) Save(db *DB) error {
+	libraries := map[string]interface{}{}
+	for _, library := range db.Libraries {
+		libraries[string(recordKey(library.Name))] = library
+	}
+	releases := map[string]interface{}{}
+	for _, release := range db.Releases {
+		releases[string(recordKey(release.LibraryName, release.Version.String()))] = release
+	}
+	rejectedReleases := map[string]interface{}{}
+	for _, rejected := range db.RejectedReleases {
+		rejectedReleases[string(recordKey(rejected.LibraryName, rejected.Tag))] = rejected
+	}
+
+	boltDB, err := bolt.Open(storage.path, 0644, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return err
+	}
+	if err := boltDB.Update(func(tx *bolt.Tx) error {
+		if err := saveBucket(tx, metadataBucket, map[string]interface{}{string(schemaVersionKey): db.SchemaVersion}); err != nil {
+			return err
+		}
+		if err := saveBucket(tx, librariesBucket, libraries); err != nil {
+			return err
+		}
+		if err := saveBucket(tx, releasesBucket, releases); err != nil {
+			return err
+		}
+		return saveBucket(tx, rejectedReleasesBucket, rejectedReleases)
+	}); err != nil {
+		boltDB.Close()
+		return err
+	}
+	return boltDB.Close()
+}
+
+// saveBucket makes the content of the bucket match the given records.
+func saveBucket(tx *bolt.Tx, name []byte, records map[string]interface{}) error {
+	bucket, err := tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return err
+	}
+
+	var removedKeys [][]byte
+	if err := bucket.ForEach(func(key []byte, value []byte) error {
+		if _, found := records[string(key)]; !found {
+			removedKeys = append(removedKeys, append([]byte(nil), key...))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, key := range removedKeys {
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	for key, record := range records {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(bucket.Get([]byte(key)), value) {
+			continue
+		}
+		if err := bucket.Put([]byte(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path returns the path of the Bolt store file.
+func (storage *BoltStorage) Path() string {
+	return storage.path
+}
+
+// Close does nothing, as the store is only open during loads and saves.
+func (storage *BoltStorage) Close() error {
+	return nil
+}
@@ -26,19 +26,30 @@ package db
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"sync"
+	"time"
 )
 
 // DB is the libraries database
 type DB struct {
-	Libraries []*Library
-	Releases  []*Release
+	SchemaVersion    int
+	Libraries        []*Library
+	Releases         []*Release
+	RejectedReleases []*RejectedRelease
 
-	libraryFile string
-	mutex       sync.Mutex
+	storage Storage
+	mutex   sync.Mutex
+	lookup  *lookup
+
+	journal        *os.File // Changes not yet saved to the storage are recorded here when batching is enabled.
+	journalChanges int
+	lastSave       time.Time
+	commitCount    int
+	commitInterval time.Duration
 }
 
 // Library is an Arduino library
@@ -69,7 +80,39 @@ type Release struct {
 	Checksum        string
 	Includes        []string
 	Dependencies    []*Dependency
-	Log             string
+	Precompiled     string            `json:",omitempty"`
+	LDFlags         string            `json:",omitempty"`
+	DotALinkage     bool              `json:",omitempty"`
+	ExtraFields     map[string]string `json:",omitempty"` // library.properties fields not in the specification.
+	// Precompiled, LDFlags, DotALinkage and ExtraFields were not recorded for releases indexed before schema version 3,
+	// so their values are unknown rather than empty.
+	PropertiesUnknown bool `json:",omitempty"`
+	Log               string
+
+	// Provenance of the release. Not available for releases indexed before schema version 2.
+	Tag                string    `json:",omitempty"`
+	CommitHash         string    `json:",omitempty"`
+	CommitDate         time.Time `json:",omitzero"` // Committer date of the tagged commit.
+	TagDate            time.Time `json:",omitzero"` // Tagger date. Zero for lightweight tags.
+	IndexTime          time.Time `json:",omitzero"` // When the release was added to the database.
+	EngineVersion      string    `json:",omitempty"`
+	ArduinoLintVersion string    `json:",omitempty"`
+}
+
+// Indexable returns whether the release has the data required for it to be added to the library index.
+func (release *Release) Indexable() bool {
+	return release.Size != 0 && release.Checksum != ""
+}
+
+// RejectedRelease is a library repository tag that did not meet the requirements for addition to the index.
+type RejectedRelease struct {
+	LibraryName string
+	Tag         string
+	CommitHash  string
+	Rules       string // Identifies the rules that were applied, so the tag is checked again if they change.
+	Reason      string
+	Output      string // Output of the failed check.
+	Time        time.Time
 }
 
 // Dependency is a library dependency
@@ -78,9 +121,14 @@ type Dependency struct {
 	Version string
 }
 
-// New returns a new DB object.
+// New returns a new DB object stored in the given JSON file.
 func New(libraryFile string) *DB {
-	return &DB{libraryFile: libraryFile}
+	return NewWithStorage(NewJSONStorage(libraryFile))
+}
+
+// NewWithStorage returns a new DB object stored in the given storage.
+func NewWithStorage(storage Storage) *DB {
+	return &DB{SchemaVersion: CurrentSchemaVersion, storage: storage}
 }
 
 // AddLibrary adds a library to the database.
@@ -91,10 +139,18 @@ func (db *DB) AddLibrary(library *Library) error {
 	if found != nil {
 		return errors.New("library already exists")
 	}
-	db.Libraries = append(db.Libraries, library)
+	if err := db.appendToJournal(&journalEntry{Library: library}); err != nil {
+		return err
+	}
+	db.addLibrary(library)
 	return nil
 }
 
+func (db *DB) addLibrary(library *Library) {
+	db.indexes().addLibrary(library)
+	db.Libraries = append(db.Libraries, library)
+}
+
 // RemoveLibrary removes a library and all its releases from the database.
 func (db *DB) RemoveLibrary(libraryName string) error {
 	db.mutex.Lock()
@@ -113,8 +169,10 @@ func (db *DB) removeLibrary(libraryName string) error {
 	if !found {
 		return errors.New("library not found")
 	}
+	db.indexes().removeLibrary(libraryName)
 
 	db.removeReleases(libraryName) // It's OK if no releases were found.
+	db.removeRejectedReleases(libraryName)
 
 	return nil
 }
@@ -139,10 +197,8 @@ func (db *DB) FindLibrary(libraryName string) (*Library, error) {
 }
 
 func (db *DB) findLibrary(libraryName string) (*Library, error) {
-	for _, lib := range db.Libraries {
-		if lib.Name == libraryName {
-			return lib, nil
-		}
+	if lib, found := db.indexes().libraryByName[libraryName]; found {
+		return lib, nil
 	}
 	return nil, errors.New("library not found")
 }
@@ -155,11 +211,18 @@ func (db *DB) AddRelease(release *Release, repoURL string) error {
 	if err != nil {
 		return err
 	}
-	lib.Repository = repoURL
-
 	if db.hasRelease(release) {
 		return errors.New("release already exists")
 	}
+	if err := db.appendToJournal(&journalEntry{Release: release, RepoURL: repoURL}); err != nil {
+		return err
+	}
+	return db.addRelease(lib, release, repoURL)
+}
+
+func (db *DB) addRelease(lib *Library, release *Release, repoURL string) error {
+	lib.Repository = repoURL
+	db.indexes().addRelease(release)
 	db.Releases = append(db.Releases, release)
 
 	// Update LatestCategory with the Category of the latest release
@@ -190,6 +253,7 @@ func (db *DB) removeReleaseByNameVersion(libraryName string, libraryVersion stri
 	if !found {
 		return errors.New("release not found")
 	}
+	db.indexes().removeReleaseByNameVersion(libraryName, libraryVersion)
 
 	return nil
 }
@@ -225,50 +289,71 @@ func (db *DB) FindRelease(release *Release) (*Release, error) {
 }
 
 func (db *DB) findReleaseByNameVersion(libraryName string, libraryVersion string) (*Release, error) {
-	for _, r := range db.Releases {
-		if r.LibraryName == libraryName && r.Version.String() == libraryVersion {
-			return r, nil
-		}
+	if r, found := db.indexes().releaseByNameVersion[releaseKey{libraryName: libraryName, version: libraryVersion}]; found {
+		return r, nil
 	}
 	return nil, errors.New("library not found")
 }
 
-// LoadFromFile returns a DB object loaded from the given filename.
+// LoadFromFile returns a DB object loaded from the given JSON file.
 func LoadFromFile(filename string) (*DB, error) {
-	file, err := os.Open(filename)
-	if err != nil {
+	return LoadFromStorage(NewJSONStorage(filename))
+}
+
+// LoadFromStorage returns a DB object loaded from the given storage.
+func LoadFromStorage(storage Storage) (*DB, error) {
+	db := NewWithStorage(storage)
+	if err := storage.Load(db); err != nil {
 		return nil, err
 	}
-	defer file.Close()
-	db, err := Load(file)
-	if err != nil {
+	if err := db.migrate(); err != nil {
 		return nil, err
 	}
-	db.libraryFile = filename
+	db.buildIndexes()
 	return db, nil
 }
 
 // Load returns a DB object loaded from the given reader.
 func Load(r io.Reader) (*DB, error) {
-	decoder := json.NewDecoder(r)
 	db := new(DB)
-	err := decoder.Decode(db)
-	if err != nil {
+	if err := db.load(r); err != nil {
+		return nil, err
+	}
+	if err := db.migrate(); err != nil {
 		return nil, err
 	}
+	db.buildIndexes()
 	return db, nil
 }
 
-// SaveToFile saves the database to a file.
+func (db *DB) load(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	return decoder.Decode(db)
+}
+
+// SaveToFile saves the database to its storage.
 func (db *DB) SaveToFile() error {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
-	file, err := os.Create(db.libraryFile)
-	if err != nil {
+	return db.saveToFile()
+}
+
+func (db *DB) saveToFile() error {
+	if db.storage == nil {
+		return errors.New("database has no storage")
+	}
+	if err := db.storage.Save(db); err != nil {
 		return err
 	}
-	defer file.Close()
-	return db.save(file)
+	db.lastSave = time.Now()
+	return nil
+}
+
+// SaveToStorage saves the content of the database to the given storage, e.g. to migrate it to another backend.
+func (db *DB) SaveToStorage(storage Storage) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return storage.Save(db)
 }
 
 // Save writes the database via the given writer.
@@ -287,6 +372,15 @@ func (db *DB) save(r io.Writer) error {
 	return err
 }
 
+// FindLatestReleaseOfLibrary returns the release of the library with the highest version, or nil if it has no releases.
+func (db *DB) FindLatestReleaseOfLibrary(lib *Library) (*Release, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return db.findLatestReleaseOfLibrary(lib)
+}
+
+// findLatestReleaseOfLibrary returns the release of the library with the highest version, as determined by
+// Version.Compare.
 func (db *DB) findLatestReleaseOfLibrary(lib *Library) (*Release, error) {
 	var found *Release
 	for _, rel := range db.findReleasesOfLibrary(lib) {
@@ -311,14 +405,8 @@ func (db *DB) FindReleasesOfLibrary(lib *Library) []*Release {
 }
 
 func (db *DB) findReleasesOfLibrary(lib *Library) []*Release {
-	var releases []*Release
-	for _, rel := range db.Releases {
-		if rel.LibraryName != lib.Name {
-			continue
-		}
-		releases = append(releases, rel)
-	}
-	return releases
+	// Return a copy so the caller can't alter the index.
+	return append([]*Release(nil), db.indexes().releasesByLibrary[lib.Name]...)
 }
 
 // RemoveReleases removes all releases of a library from the database.
@@ -339,23 +427,142 @@ func (db *DB) removeReleases(libraryName string) error {
 	if !found {
 		return errors.New("releases not found")
 	}
+	db.indexes().removeReleases(libraryName)
 
 	return nil
 }
 
-// Commit saves the database to disk.
+// AddRejectedRelease records a rejected tag, replacing any previous record of the same library tag.
+func (db *DB) AddRejectedRelease(rejected *RejectedRelease) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if err := db.appendToJournal(&journalEntry{RejectedRelease: rejected}); err != nil {
+		return err
+	}
+	db.addRejectedRelease(rejected)
+	return nil
+}
+
+func (db *DB) addRejectedRelease(rejected *RejectedRelease) {
+	db.removeRejectedRelease(rejected.LibraryName, rejected.Tag)
+	db.RejectedReleases = append(db.RejectedReleases, rejected)
+}
+
+// FindRejectedRelease returns the record of the rejection of the given library tag.
+func (db *DB) FindRejectedRelease(libraryName string, tag string) (*RejectedRelease, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for _, rejected := range db.RejectedReleases {
+		if rejected.LibraryName == libraryName && rejected.Tag == tag {
+			return rejected, nil
+		}
+	}
+	return nil, errors.New("rejected release not found")
+}
+
+// RemoveRejectedRelease removes the record of the rejection of the given library tag. It's OK if there is no record.
+func (db *DB) RemoveRejectedRelease(libraryName string, tag string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if err := db.appendToJournal(&journalEntry{RemovedRejectedRelease: &RejectedRelease{LibraryName: libraryName, Tag: tag}}); err != nil {
+		return err
+	}
+	db.removeRejectedRelease(libraryName, tag)
+	return nil
+}
+
+func (db *DB) removeRejectedRelease(libraryName string, tag string) {
+	rejectedReleases := db.RejectedReleases[:0]
+	for _, rejected := range db.RejectedReleases {
+		if rejected.LibraryName != libraryName || rejected.Tag != tag {
+			rejectedReleases = append(rejectedReleases, rejected)
+		}
+	}
+	db.RejectedReleases = rejectedReleases
+}
+
+func (db *DB) removeRejectedReleases(libraryName string) {
+	rejectedReleases := db.RejectedReleases[:0]
+	for _, rejected := range db.RejectedReleases {
+		if rejected.LibraryName != libraryName {
+			rejectedReleases = append(rejectedReleases, rejected)
+		}
+	}
+	db.RejectedReleases = rejectedReleases
+}
+
+// Commit saves the database to disk. If batching is enabled, the database file is only saved once the configured
+// number of changes or time interval is reached, the changes being safely stored in the journal in the meantime.
 func (db *DB) Commit() error {
-	return db.SaveToFile()
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if db.journal == nil {
+		return db.saveToFile()
+	}
+	if db.journalChanges == 0 {
+		return nil
+	}
+	if (db.commitCount > 0 && db.journalChanges >= db.commitCount) ||
+		(db.commitInterval > 0 && time.Since(db.lastSave) >= db.commitInterval) {
+		return db.flush()
+	}
+	return nil
 }
 
-// Init loads a database from file and returns it.
+// Open loads a database from the given storage and returns it. Changes recorded in the journal by an interrupted batch
+// are recovered. Unlike Init, it fails if the database can't be loaded.
+func Open(storage Storage) (*DB, error) {
+	libs, err := LoadFromStorage(storage)
+	if err != nil {
+		return nil, err
+	}
+	if err := libs.recoverJournal(true); err != nil {
+		return nil, err
+	}
+	return libs, nil
+}
+
+// Init loads a database from the given JSON file and returns it. Changes recorded in the journal by an interrupted
+// batch are recovered.
 func Init(libraryFile string) *DB {
-	libs, err := LoadFromFile(libraryFile)
+	libs, err := InitWithStorage(NewJSONStorage(libraryFile))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return libs
+}
+
+// InitWithStorage loads a database from the given storage and returns it, starting with an empty database if it can't
+// be loaded. Changes recorded in the journal by an interrupted batch are recovered and saved. An error is returned if
+// the database can't be used without risking the loss of data on the next save.
+func InitWithStorage(storage Storage) (*DB, error) {
+	return initWithStorage(storage, true)
+}
+
+// InitReadOnly behaves like InitWithStorage, but the changes recovered from the journal are only applied to the
+// returned database, leaving the storage and the journal unchanged.
+func InitReadOnly(storage Storage) (*DB, error) {
+	return initWithStorage(storage, false)
+}
+
+func initWithStorage(storage Storage, saveRecovered bool) (*DB, error) {
+	libs, err := LoadFromStorage(storage)
+	if errors.Is(err, ErrUnsupportedSchemaVersion) {
+		// Starting with an empty DB would overwrite the data on the next save.
+		return nil, err
+	}
 	if err != nil {
 		log.Print(err)
 		log.Print("starting with an empty DB")
-		return New(libraryFile)
+		libs = NewWithStorage(storage)
+	} else {
+		log.Printf("Loaded %v libraries from DB", len(libs.Libraries))
 	}
-	log.Printf("Loaded %v libraries from DB", len(libs.Libraries))
-	return libs
+
+	// A batch started with an unrecovered journal would discard its changes.
+	if err := libs.recoverJournal(saveRecovered); err != nil {
+		return nil, fmt.Errorf("error recovering DB journal: %w", err)
+	}
+
+	return libs, nil
 }
@@ -138,7 +138,7 @@ func testerDB() *DB {
 				Log: "Some log messages",
 			},
 		},
-		libraryFile: "some-file.json",
+		storage: NewJSONStorage("some-file.json"),
 	}
 
 	return &tDB
@@ -189,3 +189,56 @@ func TestRemoveReleases(t *testing.T) {
 	err = testDB.RemoveReleases("nonexistent")
 	assert.Error(t, err)
 }
+
+func TestAddRelease(t *testing.T) {
+	testDB := testerDB()
+	lib, err := testDB.FindLibrary("FooLib")
+	require.NoError(t, err)
+
+	err = testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.10.0"), Category: "Timing"}, lib.Repository)
+	require.NoError(t, err)
+	assert.Equal(t, "Timing", lib.LatestCategory)
+
+	// A release with a lexicographically greater but semantically lower version must not become the latest.
+	err = testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.9.0"), Category: "Display"}, lib.Repository)
+	require.NoError(t, err)
+	assert.Equal(t, "Timing", lib.LatestCategory)
+
+	// Pre-releases are lower than the associated release.
+	err = testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.10.0-rc1"), Category: "Sensors"}, lib.Repository)
+	require.NoError(t, err)
+	assert.Equal(t, "Timing", lib.LatestCategory)
+
+	err = testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.10.0")}, lib.Repository)
+	assert.Error(t, err, "Duplicate release")
+	err = testDB.AddRelease(&Release{LibraryName: "nonexistent", Version: VersionFromString("1.0.0")}, lib.Repository)
+	assert.Error(t, err, "Nonexistent library")
+}
+
+func TestRejectedReleases(t *testing.T) {
+	testDB := testerDB()
+	_, err := testDB.FindRejectedRelease("FooLib", "2.0.0")
+	assert.Error(t, err)
+
+	require.NoError(t, testDB.AddRejectedRelease(&RejectedRelease{LibraryName: "FooLib", Tag: "2.0.0", CommitHash: "abc", Reason: "lint failure"}))
+	require.NoError(t, testDB.AddRejectedRelease(&RejectedRelease{LibraryName: "BazLib", Tag: "3.0.0", CommitHash: "def", Reason: "lint failure"}))
+	rejected, err := testDB.FindRejectedRelease("FooLib", "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", rejected.CommitHash)
+
+	// The record for a tag is replaced.
+	require.NoError(t, testDB.AddRejectedRelease(&RejectedRelease{LibraryName: "FooLib", Tag: "2.0.0", CommitHash: "123", Reason: "wrong name"}))
+	assert.Len(t, testDB.RejectedReleases, 2)
+	rejected, err = testDB.FindRejectedRelease("FooLib", "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "123", rejected.CommitHash)
+
+	require.NoError(t, testDB.RemoveRejectedRelease("FooLib", "2.0.0"))
+	_, err = testDB.FindRejectedRelease("FooLib", "2.0.0")
+	assert.Error(t, err)
+	require.NoError(t, testDB.RemoveRejectedRelease("FooLib", "2.0.0"), "Removing nonexistent record is OK")
+
+	// Records are removed along with the library.
+	require.NoError(t, testDB.RemoveLibrary("BazLib"))
+	assert.Empty(t, testDB.RejectedReleases)
+}
@@ -0,0 +1,111 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"fmt"
+	"sort"
+
+	semver "go.bug.st/relaxed-semver"
+)
+
+// Constraint returns the version constraint of the dependency. A dependency without a version is satisfied by any
+// version.
+func (dependency *Dependency) Constraint() (semver.Constraint, error) {
+	return semver.ParseConstraint(dependency.Version)
+}
+
+// String returns the dependency in the format of the library.properties depends field.
+func (dependency *Dependency) String() string {
+	if dependency.Version == "" {
+		return dependency.Name
+	}
+	return dependency.Name + " (" + dependency.Version + ")"
+}
+
+// UnresolvedDependency is a dependency of an indexed release that is not satisfied by any indexed release.
+type UnresolvedDependency struct {
+	Version    string      `json:"version"` // Version of the release having the dependency.
+	Dependency *Dependency `json:"dependency"`
+	Reason     string      `json:"reason"`
+}
+
+// UnresolvedDependencies checks the dependencies of the indexed releases and returns those which can't be resolved,
+// mapped by the name of the library having them.
+func (db *DB) UnresolvedDependencies() map[string][]*UnresolvedDependency {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	unresolved := make(map[string][]*UnresolvedDependency)
+	for _, release := range db.Releases {
+		if !release.Indexable() {
+			continue
+		}
+		for _, dependency := range release.Dependencies {
+			if reason := db.checkDependency(dependency); reason != "" {
+				unresolved[release.LibraryName] = append(unresolved[release.LibraryName], &UnresolvedDependency{
+					Version:    release.Version.String(),
+					Dependency: dependency,
+					Reason:     reason,
+				})
+			}
+		}
+	}
+
+	for _, libraryUnresolved := range unresolved {
+		sort.SliceStable(libraryUnresolved, func(i, j int) bool {
+			versionI := VersionFromString(libraryUnresolved[i].Version)
+			return versionI.Compare(VersionFromString(libraryUnresolved[j].Version)) < 0
+		})
+	}
+
+	return unresolved
+}
+
+// checkDependency returns the reason why the dependency can't be resolved, or an empty string if it is satisfied by an
+// indexed release.
+func (db *DB) checkDependency(dependency *Dependency) string {
+	constraint, err := dependency.Constraint()
+	if err != nil {
+		return fmt.Sprintf("invalid version constraint: %s", err)
+	}
+	if !db.hasLibrary(dependency.Name) {
+		return "library not found"
+	}
+	for _, release := range db.indexes().releasesByLibrary[dependency.Name] {
+		if release.Indexable() && matches(constraint, release.Version) {
+			return ""
+		}
+	}
+	return "no release satisfies the version constraint"
+}
+
+// matches returns whether the version satisfies the constraint. Versions which aren't valid semver never do.
+func matches(constraint semver.Constraint, version Version) bool {
+	parsed, err := semver.Parse(version.String())
+	if err != nil {
+		return false
+	}
+	return constraint.Match(parsed)
+}
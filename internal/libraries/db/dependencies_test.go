@@ -26,6 +26,7 @@ package db
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -61,6 +62,8 @@ func TestDependencyExtract(t *testing.T) {
 	invalid("MyLib,,AnotherLib")
 	invalid("(MyLib)")
 	invalid("MyLib(=1.2.3)")
+	invalid("MyLib (foo)")
+	invalid("MyLib (>=1.2.3 &&)")
 	check("Arduino Uno WiFi Dev Ed Library, LoRa Node (^2.1.2)",
 		[]string{"Arduino Uno WiFi Dev Ed Library", "LoRa Node"},
 		[]string{"", "^2.1.2"})
@@ -72,3 +75,49 @@ func TestDependencyExtract(t *testing.T) {
 		[]string{"", "<0.3.0", ""})
 	check("", []string{}, []string{})
 }
+
+func TestParseDependencies(t *testing.T) {
+	deps, errs := ParseDependencies("MyLib (>=1.2.3), , BadLib (foo), AnotherLib")
+	require.Len(t, deps, 2)
+	assert.Equal(t, "MyLib (>=1.2.3)", deps[0].String())
+	assert.Equal(t, "AnotherLib", deps[1].String())
+	assert.Len(t, errs, 2)
+
+	constraint, err := deps[0].Constraint()
+	require.NoError(t, err)
+	assert.True(t, matches(constraint, VersionFromString("1.3.0")))
+	assert.False(t, matches(constraint, VersionFromString("1.2.0")))
+	assert.False(t, matches(constraint, VersionFromString("foo")))
+	constraint, err = deps[1].Constraint()
+	require.NoError(t, err)
+	assert.True(t, matches(constraint, VersionFromString("0.0.1")), "No version constraint")
+}
+
+func TestUnresolvedDependencies(t *testing.T) {
+	testDB := testerDB()
+	unresolved := testDB.UnresolvedDependencies()
+	require.Len(t, unresolved, 1)
+	require.Len(t, unresolved["FooLib"], 1)
+	assert.Equal(t, "1.0.0", unresolved["FooLib"][0].Version)
+	assert.Contains(t, unresolved["FooLib"][0].Reason, "invalid version constraint", "Version without operator")
+
+	release, err := testDB.FindRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.1.0")})
+	require.NoError(t, err)
+	release.Dependencies = []*Dependency{
+		{Name: "BazLib", Version: ">=2.1.0"},
+		{Name: "BazLib", Version: ">=3.0.0"},
+		{Name: "NonexistentLib"},
+		{Name: "QuxLib"},
+	}
+	// Releases which are not indexed don't satisfy dependencies and their dependencies are not checked.
+	require.NoError(t, testDB.AddRelease(&Release{LibraryName: "QuxLib", Version: VersionFromString("1.0.0"), Dependencies: []*Dependency{{Name: "NonexistentLib"}}}, "https://github.com/Zeb/QuxLib.git"))
+
+	unresolved = testDB.UnresolvedDependencies()
+	require.Len(t, unresolved, 1)
+	require.Len(t, unresolved["FooLib"], 4)
+	for i, dependency := range []string{"BazLib (>=3.0.0)", "NonexistentLib", "QuxLib"} {
+		assert.Equal(t, "1.1.0", unresolved["FooLib"][i+1].Version)
+		assert.Equal(t, dependency, unresolved["FooLib"][i+1].Dependency.String())
+	}
+	assert.Equal(t, "library not found", unresolved["FooLib"][2].Reason)
+}
@@ -23,6 +23,48 @@
 
 package db
 
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// IndexOptions configures the content of the library index.
+type IndexOptions struct {
+	ReleaseDates  bool     // Add the commit and indexing dates of the releases.
+	Types         []string // Only releases having one of these types. All releases if empty.
+	Architectures []string // Only releases compatible with one of these architectures. All releases if empty.
+	LatestOnly    bool     // Only the latest of the selected releases of each library.
+}
+
+// includes returns whether the release is selected by the options.
+func (options *IndexOptions) includes(release *Release) bool {
+	return matchesAny(release.Types, options.Types) && (matchesAny(release.Architectures, options.Architectures) || containsFold(release.Architectures, "*"))
+}
+
+// matchesAny returns whether any of the selected values is in the values, ignoring case. Empty selection matches any
+// values.
+func matchesAny(values []string, selected []string) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	for _, value := range selected {
+		if containsFold(values, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
 // Output structure used to generate library_index.json file
 type indexOutput struct {
 	Libraries []indexLibrary `json:"libraries"`
@@ -44,10 +86,15 @@ type indexLibrary struct {
 	Repository       string             `json:"repository,omitempty"`
 	ProvidesIncludes []string           `json:"providesIncludes,omitempty"`
 	Dependencies     []*indexDependency `json:"dependencies,omitempty"`
+	Precompiled      string             `json:"precompiled,omitempty"`
+	LDFlags          string             `json:"ldflags,omitempty"`
+	DotALinkage      bool               `json:"dotALinkage,omitempty"`
 	URL              string             `json:"url"`
 	ArchiveFileName  string             `json:"archiveFileName"`
 	Size             int64              `json:"size"`
 	Checksum         string             `json:"checksum"`
+	CommitDate       time.Time          `json:"commitDate,omitzero"`
+	IndexDate        time.Time          `json:"indexDate,omitzero"`
 }
 
 type indexDependency struct {
@@ -57,17 +104,36 @@ type indexDependency struct {
 
 // OutputLibraryIndex generates an object that once JSON-marshaled produces a json
 // file suitable for the library installer (i.e. produce a valid library_index.json file)
-func (db *DB) OutputLibraryIndex() (interface{}, error) {
+//
+// The releases are sorted by library name and then by version, so that the same database content always produces the
+// same index.
+func (db *DB) OutputLibraryIndex(options IndexOptions) (interface{}, error) {
 	libraries := make([]indexLibrary, 0, len(db.Libraries))
 
-	for _, lib := range db.Libraries {
+	sortedLibraries := append([]*Library{}, db.Libraries...)
+	sort.Slice(sortedLibraries, func(i, j int) bool { return sortedLibraries[i].Name < sortedLibraries[j].Name })
+	for _, lib := range sortedLibraries {
 		libraryReleases := db.FindReleasesOfLibrary(lib)
+		sort.Slice(libraryReleases, func(i, j int) bool {
+			if comparison := libraryReleases[i].Version.Compare(libraryReleases[j].Version); comparison != 0 {
+				return comparison < 0
+			}
+			// Versions differing only by build metadata have the same precedence.
+			return libraryReleases[i].Version.String() < libraryReleases[j].Version.String()
+		})
 
+		selectedReleases := []*Release{}
 		for _, libraryRelease := range libraryReleases {
-			// Skip malformed release
-			if libraryRelease.Size == 0 || libraryRelease.Checksum == "" {
-				continue
+			// Skip malformed releases and those not selected by the options.
+			if libraryRelease.Indexable() && options.includes(libraryRelease) {
+				selectedReleases = append(selectedReleases, libraryRelease)
 			}
+		}
+		if options.LatestOnly && len(selectedReleases) > 0 {
+			selectedReleases = selectedReleases[len(selectedReleases)-1:]
+		}
+
+		for _, libraryRelease := range selectedReleases {
 
 			deps := []*indexDependency{}
 			for _, dep := range libraryRelease.Dependencies {
@@ -78,7 +144,7 @@ func (db *DB) OutputLibraryIndex() (interface{}, error) {
 			}
 
 			// Copy db.Library into db.indexLibrary
-			libraries = append(libraries, indexLibrary{
+			indexRelease := indexLibrary{
 				LibraryName:      libraryRelease.LibraryName,
 				Version:          libraryRelease.Version,
 				Author:           libraryRelease.Author,
@@ -97,7 +163,15 @@ func (db *DB) OutputLibraryIndex() (interface{}, error) {
 				Repository:       lib.Repository,
 				ProvidesIncludes: libraryRelease.Includes,
 				Dependencies:     deps,
-			})
+				Precompiled:      libraryRelease.Precompiled,
+				LDFlags:          libraryRelease.LDFlags,
+				DotALinkage:      libraryRelease.DotALinkage,
+			}
+			if options.ReleaseDates {
+				indexRelease.CommitDate = libraryRelease.CommitDate
+				indexRelease.IndexDate = libraryRelease.IndexTime
+			}
+			libraries = append(libraries, indexRelease)
 		}
 
 	}
@@ -0,0 +1,148 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputLibraryIndexReleaseDates(t *testing.T) {
+	testDB := testerDB()
+	release, err := testDB.FindRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.0.0")})
+	require.NoError(t, err)
+	release.CommitDate = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	release.IndexTime = time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	index, err := testDB.OutputLibraryIndex(IndexOptions{})
+	require.NoError(t, err)
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "commitDate")
+	assert.NotContains(t, string(data), "indexDate")
+
+	index, err = testDB.OutputLibraryIndex(IndexOptions{ReleaseDates: true})
+	require.NoError(t, err)
+	data, err = json.Marshal(index)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"commitDate":"2026-01-02T03:04:05Z","indexDate":"2026-02-03T04:05:06Z"`)
+	assert.Equal(t, 1, strings.Count(string(data), "commitDate"), "Dates omitted for releases without provenance")
+}
+
+func TestOutputLibraryIndexOrder(t *testing.T) {
+	testDB := testerDB()
+	lib, err := testDB.FindLibrary("FooLib")
+	require.NoError(t, err)
+	for _, version := range []string{"1.10.0", "1.9.0", "1.10.0-rc1"} {
+		release := *testDB.Releases[0]
+		release.Version = VersionFromString(version)
+		require.NoError(t, testDB.AddRelease(&release, lib.Repository))
+	}
+
+	index, err := testDB.OutputLibraryIndex(IndexOptions{})
+	require.NoError(t, err)
+	var releases []string
+	for _, release := range index.(*indexOutput).Libraries {
+		releases = append(releases, release.LibraryName+"@"+release.Version.String())
+	}
+	assert.Equal(t, []string{"BazLib@2.0.0", "BazLib@2.1.0", "FooLib@1.0.0", "FooLib@1.1.0", "FooLib@1.9.0", "FooLib@1.10.0-rc1", "FooLib@1.10.0"}, releases)
+
+	// The output doesn't depend on the order of the database content.
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		rand.Shuffle(len(testDB.Libraries), func(i, j int) { testDB.Libraries[i], testDB.Libraries[j] = testDB.Libraries[j], testDB.Libraries[i] })
+		rand.Shuffle(len(testDB.Releases), func(i, j int) { testDB.Releases[i], testDB.Releases[j] = testDB.Releases[j], testDB.Releases[i] })
+		testDB.lookup = nil
+		shuffledIndex, err := testDB.OutputLibraryIndex(IndexOptions{})
+		require.NoError(t, err)
+		shuffledData, err := json.Marshal(shuffledIndex)
+		require.NoError(t, err)
+		assert.Equal(t, string(data), string(shuffledData))
+	}
+}
+
+func TestOutputLibraryIndexFilters(t *testing.T) {
+	testDB := testerDB()
+	lib, err := testDB.FindLibrary("QuxLib")
+	require.NoError(t, err)
+	for _, release := range []*Release{
+		{Version: VersionFromString("1.0.0"), Architectures: []string{"*"}, Types: []string{"Arduino"}},
+		{Version: VersionFromString("1.1.0"), Architectures: []string{"samd"}, Types: []string{"Arduino"}},
+		{Version: VersionFromString("1.2.0"), Architectures: []string{"samd"}, Types: []string{"Contributed"}},
+	} {
+		release.LibraryName = "QuxLib"
+		release.URL = "http://www.example.com/libraries/github.com/Zeb/QuxLib-" + release.Version.String() + ".zip"
+		release.ArchiveFileName = "QuxLib-" + release.Version.String() + ".zip"
+		release.Size = 123
+		release.Checksum = "SHA-256:887f897cfb1818a53652aef39c2a4b8de3c69c805520b2953a562a787b422420"
+		require.NoError(t, testDB.AddRelease(release, lib.Repository))
+	}
+
+	testTables := []struct {
+		testName string
+		options  IndexOptions
+		releases []string
+	}{
+		{"No filter", IndexOptions{}, []string{"BazLib@2.0.0", "BazLib@2.1.0", "FooLib@1.0.0", "FooLib@1.1.0", "QuxLib@1.0.0", "QuxLib@1.1.0", "QuxLib@1.2.0"}},
+		{"Type", IndexOptions{Types: []string{"arduino"}}, []string{"QuxLib@1.0.0", "QuxLib@1.1.0"}},
+		{"Architecture", IndexOptions{Architectures: []string{"samd"}}, []string{"QuxLib@1.0.0", "QuxLib@1.1.0", "QuxLib@1.2.0"}},
+		{"Architectures", IndexOptions{Architectures: []string{"esp32", "avr"}}, []string{"BazLib@2.0.0", "BazLib@2.1.0", "FooLib@1.0.0", "FooLib@1.1.0", "QuxLib@1.0.0"}},
+		{"Latest", IndexOptions{LatestOnly: true}, []string{"BazLib@2.1.0", "FooLib@1.1.0", "QuxLib@1.2.0"}},
+		{"Latest of type", IndexOptions{Types: []string{"Arduino"}, LatestOnly: true}, []string{"QuxLib@1.1.0"}},
+	}
+	for _, testTable := range testTables {
+		index, err := testDB.OutputLibraryIndex(testTable.options)
+		require.NoError(t, err, testTable.testName)
+		releases := []string{}
+		for _, release := range index.(*indexOutput).Libraries {
+			releases = append(releases, release.LibraryName+"@"+release.Version.String())
+		}
+		assert.Equal(t, testTable.releases, releases, testTable.testName)
+	}
+}
+
+func TestOutputLibraryIndexPrecompiled(t *testing.T) {
+	testDB := testerDB()
+	release, err := testDB.FindRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.0.0")})
+	require.NoError(t, err)
+	release.Precompiled = "full"
+	release.LDFlags = "-lm"
+	release.DotALinkage = true
+	release.ExtraFields = map[string]string{"foo": "bar"}
+
+	index, err := testDB.OutputLibraryIndex(IndexOptions{})
+	require.NoError(t, err)
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"precompiled":"full","ldflags":"-lm","dotALinkage":true`)
+	assert.Equal(t, 1, strings.Count(string(data), "precompiled"), "Fields omitted for releases without precompiled binaries")
+	assert.NotContains(t, string(data), "foo", "Extra fields are not in the index")
+}
@@ -0,0 +1,210 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// JournalSuffix is appended to the database file path to get the path of its journal.
+const JournalSuffix = ".journal"
+
+// journalEntry is a change to the database recorded in the journal.
+type journalEntry struct {
+	Library                *Library         `json:",omitempty"`
+	Release                *Release         `json:",omitempty"`
+	RepoURL                string           `json:",omitempty"`
+	RejectedRelease        *RejectedRelease `json:",omitempty"`
+	RemovedRejectedRelease *RejectedRelease `json:",omitempty"`
+}
+
+// StartBatch enables batching of the database saves. Until Close is called, changes are appended to a journal file
+// and the database file is only saved by Commit once commitCount changes were made or commitInterval elapsed since the
+// last save. A zero value disables the respective condition.
+func (db *DB) StartBatch(commitCount int, commitInterval time.Duration) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if db.journal != nil {
+		return errors.New("batch already started")
+	}
+	journal, err := os.OpenFile(db.journalFile(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	db.journal = journal
+	db.commitCount = commitCount
+	db.commitInterval = commitInterval
+	db.lastSave = time.Now()
+	return nil
+}
+
+// Flush saves the database to disk regardless of the batch conditions.
+func (db *DB) Flush() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if db.journal == nil {
+		return db.saveToFile()
+	}
+	return db.flush()
+}
+
+// Close saves the database to disk, ends the batch started by StartBatch and closes the storage. The database can't be
+// saved after it is closed.
+func (db *DB) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if db.journal != nil {
+		if err := db.flush(); err != nil {
+			return err
+		}
+		if err := db.journal.Close(); err != nil {
+			return err
+		}
+		db.journal = nil
+		if err := os.Remove(db.journalFile()); err != nil {
+			return err
+		}
+	}
+	if db.storage == nil {
+		return nil
+	}
+	return db.storage.Close()
+}
+
+func (db *DB) journalFile() string {
+	if db.storage == nil {
+		return ""
+	}
+	return db.storage.Path() + JournalSuffix
+}
+
+// flush saves the database file, after which the journal content is no longer needed.
+func (db *DB) flush() error {
+	if err := db.saveToFile(); err != nil {
+		return err
+	}
+	if err := db.journal.Truncate(0); err != nil {
+		return err
+	}
+	db.journalChanges = 0
+	return nil
+}
+
+// appendToJournal durably records the change in the journal, if batching is enabled.
+func (db *DB) appendToJournal(entry *journalEntry) error {
+	if db.journal == nil {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := db.journal.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err := db.journal.Sync(); err != nil {
+		return err
+	}
+	db.journalChanges++
+	return nil
+}
+
+// recoverJournal applies the changes recorded in a journal left behind by an interrupted batch. If save is true, the
+// database is saved and the journal removed.
+func (db *DB) recoverJournal(save bool) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	journal, err := os.Open(db.journalFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer journal.Close()
+
+	recovered, err := db.replayJournal(journal)
+	if err != nil {
+		return err
+	}
+	if recovered > 0 {
+		log.Printf("Recovered %v changes from DB journal", recovered)
+	}
+	if !save {
+		return nil
+	}
+	if recovered > 0 {
+		if err := db.saveToFile(); err != nil {
+			return err
+		}
+	}
+	return os.Remove(db.journalFile())
+}
+
+// replayJournal applies the changes from the journal that are not already present in the database and returns their
+// count.
+func (db *DB) replayJournal(r io.Reader) (int, error) {
+	decoder := json.NewDecoder(r)
+	recovered := 0
+	for {
+		var entry journalEntry
+		if err := decoder.Decode(&entry); err != nil {
+			if err != io.EOF {
+				// The last entry may be incomplete if the process was interrupted while writing it.
+				log.Printf("Stopped reading DB journal at invalid entry: %s", err)
+			}
+			return recovered, nil
+		}
+
+		switch {
+		case entry.Library != nil:
+			if !db.hasLibrary(entry.Library.Name) {
+				db.addLibrary(entry.Library)
+				recovered++
+			}
+		case entry.Release != nil:
+			lib, err := db.findLibrary(entry.Release.LibraryName)
+			if err != nil {
+				return recovered, err
+			}
+			if !db.hasRelease(entry.Release) {
+				if err := db.addRelease(lib, entry.Release, entry.RepoURL); err != nil {
+					return recovered, err
+				}
+				recovered++
+			}
+		case entry.RejectedRelease != nil:
+			db.addRejectedRelease(entry.RejectedRelease)
+			recovered++
+		case entry.RemovedRejectedRelease != nil:
+			db.removeRejectedRelease(entry.RemovedRejectedRelease.LibraryName, entry.RemovedRejectedRelease.Tag)
+			recovered++
+		}
+	}
+}
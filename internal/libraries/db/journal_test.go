@@ -0,0 +1,122 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	dbFolder, err := paths.MkTempDir("", "db-TestBatch")
+	require.NoError(t, err)
+	defer dbFolder.RemoveAll()
+	dbPath := dbFolder.Join("db.json")
+	journalPath := paths.New(dbPath.String() + JournalSuffix)
+
+	testDB := New(dbPath.String())
+	require.NoError(t, testDB.StartBatch(3, 0))
+	require.NoError(t, testDB.AddLibrary(&Library{Name: "FooLib"}))
+	require.NoError(t, testDB.Commit())
+	require.NoError(t, testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.0.0")}, "https://github.com/Bar/FooLib.git"))
+	require.NoError(t, testDB.Commit())
+	assert.False(t, dbPath.Exist(), "Database not saved before reaching commit count")
+	assert.True(t, journalPath.Exist())
+
+	// Simulate an interruption by loading the database while the batch is in progress.
+	testDB = Init(dbPath.String())
+	assert.True(t, testDB.HasLibrary("FooLib"))
+	assert.True(t, testDB.HasReleaseByNameVersion("FooLib", "1.0.0"))
+	assert.True(t, dbPath.Exist(), "Recovered database saved")
+	assert.False(t, journalPath.Exist(), "Journal removed after recovery")
+
+	require.NoError(t, testDB.StartBatch(2, 0))
+	require.NoError(t, testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.1.0")}, "https://github.com/Bar/FooLib.git"))
+	require.NoError(t, testDB.Commit())
+	require.NoError(t, testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.2.0")}, "https://github.com/Bar/FooLib.git"))
+	require.NoError(t, testDB.Commit())
+	savedDB, err := LoadFromFile(dbPath.String())
+	require.NoError(t, err)
+	assert.True(t, savedDB.HasReleaseByNameVersion("FooLib", "1.2.0"), "Database saved on reaching commit count")
+
+	require.NoError(t, testDB.AddRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.3.0")}, "https://github.com/Bar/FooLib.git"))
+	require.NoError(t, testDB.Close())
+	savedDB, err = LoadFromFile(dbPath.String())
+	require.NoError(t, err)
+	assert.True(t, savedDB.HasReleaseByNameVersion("FooLib", "1.3.0"), "Database saved on close")
+	assert.False(t, journalPath.Exist(), "Journal removed on close")
+}
+
+func TestRecoverIncompleteJournal(t *testing.T) {
+	dbFolder, err := paths.MkTempDir("", "db-TestRecoverIncompleteJournal")
+	require.NoError(t, err)
+	defer dbFolder.RemoveAll()
+	dbPath := dbFolder.Join("db.json")
+	journalPath := paths.New(dbPath.String() + JournalSuffix)
+
+	require.NoError(t, journalPath.WriteFile([]byte(`{"Library":{"Name":"FooLib","Repository":"https://github.com/Bar/FooLib.git"}}
+{"Release":{"LibraryName":"FooLib","Version":"1.0.0"},"RepoURL":"https://github.com/Bar/FooLib.git"}
+{"Release":{"LibraryName":"FooLib","Vers`)))
+
+	recoveredDB := Init(dbPath.String())
+	assert.True(t, recoveredDB.HasLibrary("FooLib"))
+	assert.True(t, recoveredDB.HasReleaseByNameVersion("FooLib", "1.0.0"))
+	assert.False(t, journalPath.Exist(), "Journal removed after recovery")
+}
+
+func TestRecoverJournalReadOnly(t *testing.T) {
+	dbFolder, err := paths.MkTempDir("", "db-TestRecoverJournalReadOnly")
+	require.NoError(t, err)
+	defer dbFolder.RemoveAll()
+	dbPath := dbFolder.Join("db.json")
+	journalPath := paths.New(dbPath.String() + JournalSuffix)
+
+	require.NoError(t, journalPath.WriteFile([]byte(`{"Library":{"Name":"FooLib","Repository":"https://github.com/Bar/FooLib.git"}}
+`)))
+
+	recoveredDB, err := InitReadOnly(NewJSONStorage(dbPath.String()))
+	require.NoError(t, err)
+	assert.True(t, recoveredDB.HasLibrary("FooLib"))
+	assert.False(t, dbPath.Exist(), "Recovered database not saved")
+	assert.True(t, journalPath.Exist(), "Journal kept")
+}
+
+func TestRecoverJournalFailure(t *testing.T) {
+	dbFolder, err := paths.MkTempDir("", "db-TestRecoverJournalFailure")
+	require.NoError(t, err)
+	defer dbFolder.RemoveAll()
+	dbPath := dbFolder.Join("db.json")
+	journalPath := paths.New(dbPath.String() + JournalSuffix)
+
+	// A release of a library not in the database can't be recovered.
+	require.NoError(t, journalPath.WriteFile([]byte(`{"Release":{"LibraryName":"FooLib","Version":"1.0.0"},"RepoURL":"https://github.com/Bar/FooLib.git"}
+`)))
+
+	_, err = InitWithStorage(NewJSONStorage(dbPath.String()))
+	assert.Error(t, err)
+	assert.True(t, journalPath.Exist(), "Journal kept for manual recovery")
+}
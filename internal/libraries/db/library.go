@@ -24,17 +24,16 @@
 package db
 
 import (
-	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/arduino/libraries-repository-engine/internal/libraries/metadata"
 )
 
 // FromLibraryToRelease extract a Release from LibraryMetadata. LibraryMetadata must be
-// validated before running this function.
-func FromLibraryToRelease(library *metadata.LibraryMetadata) *Release {
-	deps, _ := ExtractDependenciesList(library.Depends)
+// validated before running this function. Invalid entries of the depends field are omitted from the release's
+// dependencies and returned as errors.
+func FromLibraryToRelease(library *metadata.LibraryMetadata) (*Release, []error) {
+	deps, errs := ParseDependencies(library.Depends)
 	dbRelease := Release{
 		LibraryName:   library.Name,
 		Version:       VersionFromString(library.Version),
@@ -49,9 +48,13 @@ func FromLibraryToRelease(library *metadata.LibraryMetadata) *Release {
 		Types:         library.Types,
 		Includes:      extractStringList(library.Includes),
 		Dependencies:  deps,
+		Precompiled:   library.Precompiled,
+		LDFlags:       library.LDFlags,
+		DotALinkage:   library.DotALinkage,
+		ExtraFields:   library.ExtraFields,
 	}
 
-	return &dbRelease
+	return &dbRelease, errs
 }
 
 func extractStringList(value string) []string {
@@ -66,28 +69,34 @@ func extractStringList(value string) []string {
 	return res
 }
 
-var re = regexp.MustCompile("^([^()]+?) *(?: \\((.*)\\))?$")
-
 // ExtractDependenciesList extracts dependencies from the "depends" field of library.properties
 func ExtractDependenciesList(depends string) ([]*Dependency, error) {
+	deps, errs := ParseDependencies(depends)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return deps, nil
+}
+
+// ParseDependencies extracts dependencies from the "depends" field of library.properties. Invalid entries are skipped
+// and returned as errors.
+func ParseDependencies(depends string) ([]*Dependency, []error) {
 	deps := []*Dependency{}
+	var errs []error
 	depends = strings.TrimSpace(depends)
 	if depends == "" {
 		return deps, nil
 	}
 	for _, dep := range strings.Split(depends, ",") {
-		dep = strings.TrimSpace(dep)
-		if dep == "" {
-			return nil, fmt.Errorf("invalid dep: %s", dep)
-		}
-		matches := re.FindAllStringSubmatch(dep, -1)
-		if matches == nil {
-			return nil, fmt.Errorf("invalid dep: %s", dep)
+		name, version, err := metadata.ParseDependency(dep)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
 		deps = append(deps, &Dependency{
-			Name:    matches[0][1],
-			Version: matches[0][2],
+			Name:    name,
+			Version: version,
 		})
 	}
-	return deps, nil
+	return deps, errs
 }
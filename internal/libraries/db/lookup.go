@@ -0,0 +1,102 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+// releaseKey identifies a release by library name and version.
+type releaseKey struct {
+	libraryName string
+	version     string
+}
+
+// lookup holds the indexes used to find libraries and releases without scanning the whole database. It is built from
+// DB.Libraries and DB.Releases on first use and kept up to date by the functions that modify them.
+type lookup struct {
+	libraryByName        map[string]*Library
+	releasesByLibrary    map[string][]*Release // Releases of each library, in DB.Releases order.
+	releaseByNameVersion map[releaseKey]*Release
+}
+
+// indexes returns the lookup indexes of the database, building them if needed.
+func (db *DB) indexes() *lookup {
+	if db.lookup == nil {
+		db.buildIndexes()
+	}
+	return db.lookup
+}
+
+// buildIndexes (re)builds the lookup indexes from the content of the database.
+func (db *DB) buildIndexes() {
+	db.lookup = &lookup{
+		libraryByName:        make(map[string]*Library, len(db.Libraries)),
+		releasesByLibrary:    make(map[string][]*Release, len(db.Libraries)),
+		releaseByNameVersion: make(map[releaseKey]*Release, len(db.Releases)),
+	}
+	for _, library := range db.Libraries {
+		db.lookup.addLibrary(library)
+	}
+	for _, release := range db.Releases {
+		db.lookup.addRelease(release)
+	}
+}
+
+func (l *lookup) addLibrary(library *Library) {
+	// In case of duplicates, the first one wins, as it would when scanning DB.Libraries.
+	if _, exists := l.libraryByName[library.Name]; !exists {
+		l.libraryByName[library.Name] = library
+	}
+}
+
+func (l *lookup) removeLibrary(libraryName string) {
+	delete(l.libraryByName, libraryName)
+}
+
+func (l *lookup) addRelease(release *Release) {
+	l.releasesByLibrary[release.LibraryName] = append(l.releasesByLibrary[release.LibraryName], release)
+	key := releaseKey{libraryName: release.LibraryName, version: release.Version.String()}
+	if _, exists := l.releaseByNameVersion[key]; !exists {
+		l.releaseByNameVersion[key] = release
+	}
+}
+
+func (l *lookup) removeReleases(libraryName string) {
+	for _, release := range l.releasesByLibrary[libraryName] {
+		delete(l.releaseByNameVersion, releaseKey{libraryName: libraryName, version: release.Version.String()})
+	}
+	delete(l.releasesByLibrary, libraryName)
+}
+
+func (l *lookup) removeReleaseByNameVersion(libraryName string, libraryVersion string) {
+	delete(l.releaseByNameVersion, releaseKey{libraryName: libraryName, version: libraryVersion})
+	releases := []*Release{}
+	for _, release := range l.releasesByLibrary[libraryName] {
+		if release.Version.String() != libraryVersion {
+			releases = append(releases, release)
+		}
+	}
+	if len(releases) == 0 {
+		delete(l.releasesByLibrary, libraryName)
+	} else {
+		l.releasesByLibrary[libraryName] = releases
+	}
+}
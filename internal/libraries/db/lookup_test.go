@@ -0,0 +1,112 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupIndexes(t *testing.T) {
+	testDB := testerDB()
+	release, err := testDB.FindRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.1.0")})
+	require.NoError(t, err)
+	assert.Equal(t, "FooLib", release.LibraryName)
+	assert.Len(t, testDB.FindReleasesOfLibrary(&Library{Name: "FooLib"}), 2)
+
+	// The indexes are kept up to date by modifications.
+	require.NoError(t, testDB.AddLibrary(&Library{Name: "NewLib"}))
+	assert.True(t, testDB.HasLibrary("NewLib"))
+	require.NoError(t, testDB.AddRelease(&Release{LibraryName: "NewLib", Version: VersionFromString("1.0.0")}, "https://github.com/Bar/NewLib.git"))
+	assert.True(t, testDB.HasReleaseByNameVersion("NewLib", "1.0.0"))
+
+	require.NoError(t, testDB.RemoveReleaseByNameVersion("FooLib", "1.0.0"))
+	releases := testDB.FindReleasesOfLibrary(&Library{Name: "FooLib"})
+	require.Len(t, releases, 1)
+	assert.Equal(t, "1.1.0", releases[0].Version.String())
+
+	require.NoError(t, testDB.RemoveLibrary("FooLib"))
+	assert.False(t, testDB.HasLibrary("FooLib"))
+	assert.Empty(t, testDB.FindReleasesOfLibrary(&Library{Name: "FooLib"}))
+
+	// The indexes are rebuilt on load.
+	buffer := new(bytes.Buffer)
+	require.NoError(t, testDB.Save(buffer))
+	loadedDB, err := Load(buffer)
+	require.NoError(t, err)
+	assert.True(t, loadedDB.HasReleaseByNameVersion("NewLib", "1.0.0"))
+	assert.False(t, loadedDB.HasLibrary("FooLib"))
+	assert.Len(t, loadedDB.FindReleasesOfLibrary(&Library{Name: "BazLib"}), 2)
+}
+
+// benchmarkDB returns a database with the given number of libraries, each with the given number of releases.
+func benchmarkDB(b *testing.B, librariesCount int, releasesPerLibrary int) *DB {
+	benchmarkDB := New("")
+	for libraryIndex := 0; libraryIndex < librariesCount; libraryIndex++ {
+		library := &Library{Name: fmt.Sprintf("Lib%d", libraryIndex)}
+		require.NoError(b, benchmarkDB.AddLibrary(library))
+		for releaseIndex := 0; releaseIndex < releasesPerLibrary; releaseIndex++ {
+			release := &Release{
+				LibraryName: library.Name,
+				Version:     VersionFromString(fmt.Sprintf("1.%d.0", releaseIndex)),
+				Size:        1,
+				Checksum:    "SHA-256:0",
+			}
+			require.NoError(b, benchmarkDB.AddRelease(release, ""))
+		}
+	}
+	return benchmarkDB
+}
+
+func BenchmarkOutputLibraryIndex(b *testing.B) {
+	for _, librariesCount := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("libraries=%d", librariesCount), func(b *testing.B) {
+			benchmarkDB := benchmarkDB(b, librariesCount, 5)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := benchmarkDB.OutputLibraryIndex(IndexOptions{})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func BenchmarkFindReleaseByNameVersion(b *testing.B) {
+	for _, librariesCount := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("libraries=%d", librariesCount), func(b *testing.B) {
+			benchmarkDB := benchmarkDB(b, librariesCount, 5)
+			release := &Release{LibraryName: fmt.Sprintf("Lib%d", librariesCount-1), Version: VersionFromString("1.4.0")}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if !benchmarkDB.HasRelease(release) {
+					b.Fatal("release not found")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// CurrentSchemaVersion is the version of the database schema used by this version of the engine.
+const CurrentSchemaVersion = 3
+
+// ErrUnsupportedSchemaVersion is returned when loading a database whose schema is newer than CurrentSchemaVersion.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported database schema version")
+
+// migration upgrades the database content from the previous schema version.
+type migration struct {
+	version     int // Schema version of the migrated database.
+	description string
+	migrate     func(db *DB) error
+}
+
+// migrations are applied in order to upgrade the content of a database loaded with an older schema. When changing the
+// data model, append a migration for the next version and update CurrentSchemaVersion.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "add schema version",
+		migrate:     func(db *DB) error { return nil }, // Version 0 databases predate versioning, the data is unchanged.
+	},
+	{
+		version:     2,
+		description: "add release provenance",
+		migrate:     func(db *DB) error { return nil }, // The provenance of existing releases is unknown.
+	},
+	{
+		version:     3,
+		description: "add precompiled, ldflags, dot_a_linkage and extra library.properties fields",
+		migrate: func(db *DB) error {
+			for _, release := range db.Releases {
+				release.PropertiesUnknown = true
+			}
+			return nil
+		},
+	},
+}
+
+// migrate upgrades the loaded database content to CurrentSchemaVersion.
+func (db *DB) migrate() error {
+	if db.SchemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("%w %d, this engine supports up to version %d", ErrUnsupportedSchemaVersion, db.SchemaVersion, CurrentSchemaVersion)
+	}
+	for _, migration := range migrations {
+		if migration.version <= db.SchemaVersion {
+			continue
+		}
+		if err := migration.migrate(db); err != nil {
+			return fmt.Errorf("migrating database to schema version %d (%s): %w", migration.version, migration.description, err)
+		}
+		log.Printf("Migrated database to schema version %d (%s)", migration.version, migration.description)
+		db.SchemaVersion = migration.version
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationsRegistry(t *testing.T) {
+	for index, migration := range migrations {
+		assert.Equal(t, index+1, migration.version, "Migrations are ordered and contiguous")
+		assert.NotEmpty(t, migration.description)
+	}
+	assert.Equal(t, CurrentSchemaVersion, migrations[len(migrations)-1].version, "Last migration produces the current schema")
+}
+
+func TestLoadUnversioned(t *testing.T) {
+	loadedDB, err := Load(strings.NewReader(`{"Libraries":[{"Name":"FooLib"}],"Releases":[]}`))
+	require.NoError(t, err)
+	assert.Equal(t, CurrentSchemaVersion, loadedDB.SchemaVersion)
+	assert.True(t, loadedDB.HasLibrary("FooLib"))
+
+	buffer := new(bytes.Buffer)
+	require.NoError(t, loadedDB.Save(buffer))
+	assert.Contains(t, buffer.String(), fmt.Sprintf(`"SchemaVersion": %d`, CurrentSchemaVersion))
+}
+
+func TestMigrateV2(t *testing.T) {
+	loadedDB, err := Load(strings.NewReader(`{"SchemaVersion":2,"Libraries":[{"Name":"FooLib"}],"Releases":[{"LibraryName":"FooLib","Version":"1.0.0"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, loadedDB.SchemaVersion)
+	assert.True(t, loadedDB.Releases[0].PropertiesUnknown, "Fields not recorded by schema version 2")
+
+	buffer := new(bytes.Buffer)
+	require.NoError(t, loadedDB.Save(buffer))
+	reloadedDB, err := Load(buffer)
+	require.NoError(t, err)
+	assert.True(t, reloadedDB.Releases[0].PropertiesUnknown)
+
+	loadedDB, err = Load(strings.NewReader(`{"SchemaVersion":3,"Libraries":[{"Name":"FooLib"}],"Releases":[{"LibraryName":"FooLib","Version":"1.0.0"}]}`))
+	require.NoError(t, err)
+	assert.False(t, loadedDB.Releases[0].PropertiesUnknown, "Fields recorded by schema version 3")
+}
+
+func TestLoadNewerSchema(t *testing.T) {
+	_, err := Load(strings.NewReader(fmt.Sprintf(`{"SchemaVersion":%d,"Libraries":[]}`, CurrentSchemaVersion+1)))
+	assert.True(t, errors.Is(err, ErrUnsupportedSchemaVersion))
+}
+
+func TestMigrate(t *testing.T) {
+	defer func(original []migration) { migrations = original }(migrations)
+
+	var applied []int
+	record := func(db *DB) error {
+		applied = append(applied, db.SchemaVersion)
+		return nil
+	}
+	migrations = []migration{
+		{version: 1, description: "first", migrate: record},
+	}
+	testDB := &DB{SchemaVersion: 0}
+	require.NoError(t, testDB.migrate())
+	assert.Equal(t, []int{0}, applied)
+	assert.Equal(t, 1, testDB.SchemaVersion)
+	require.NoError(t, testDB.migrate())
+	assert.Equal(t, []int{0}, applied, "Migrations are only applied once")
+
+	migrations = []migration{
+		{version: 1, description: "failing", migrate: func(db *DB) error { return errors.New("failure") }},
+	}
+	testDB = &DB{SchemaVersion: 0}
+	assert.Error(t, testDB.migrate())
+	assert.Zero(t, testDB.SchemaVersion, "Version not updated by failed migration")
+}
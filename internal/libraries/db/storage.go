@@ -0,0 +1,134 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Storage backends, as named in the configuration.
+const (
+	JSONBackend = "json"
+	BoltBackend = "bolt"
+)
+
+// Storage persists the content of the database.
+type Storage interface {
+	// Load reads the stored content into the database.
+	Load(db *DB) error
+	// Save writes the content of the database. An interrupted save must leave the previously stored content intact.
+	Save(db *DB) error
+	// Path returns the path of the file where the content is stored.
+	Path() string
+	// Close releases the resources used by the storage.
+	Close() error
+}
+
+// OpenStorage returns the storage of the given backend at the given path. The JSON backend is used if backend is empty.
+func OpenStorage(backend string, path string) (Storage, error) {
+	switch backend {
+	case "", JSONBackend:
+		return NewJSONStorage(path), nil
+	case BoltBackend:
+		return NewBoltStorage(path), nil
+	default:
+		return nil, fmt.Errorf("unknown database backend %q", backend)
+	}
+}
+
+// LoadExisting loads the database from the storage of the given backend at the given path. Unlike InitWithStorage, it
+// returns an error if the database doesn't exist rather than starting with an empty one, as needed by the commands that
+// only read the database.
+func LoadExisting(backend string, path string) (*DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("database file not found at %s", path)
+		}
+		return nil, err
+	}
+
+	storage, err := OpenStorage(backend, path)
+	if err != nil {
+		return nil, err
+	}
+	defer storage.Close() // The content is kept in memory.
+	return LoadFromStorage(storage)
+}
+
+// JSONStorage stores the database in a JSON file, which is rewritten entirely on each save.
+type JSONStorage struct {
+	path string
+}
+
+// NewJSONStorage returns a storage using the JSON file at the given path.
+func NewJSONStorage(path string) *JSONStorage {
+	return &JSONStorage{path: path}
+}
+
+// Load reads the database from the JSON file.
+func (storage *JSONStorage) Load(db *DB) error {
+	file, err := os.Open(storage.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return db.load(file)
+}
+
+// Save writes the database to a temporary file which then replaces the database file, so that an interrupted save
+// doesn't corrupt the database.
+func (storage *JSONStorage) Save(db *DB) error {
+	file, err := os.CreateTemp(filepath.Dir(storage.path), filepath.Base(storage.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name()) // It's OK if the file was already renamed.
+	if err := db.save(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), storage.path)
+}
+
+// Path returns the path of the JSON file.
+func (storage *JSONStorage) Path() string {
+	return storage.path
+}
+
+// Close does nothing, as the file is only open during loads and saves.
+func (storage *JSONStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package db
+
+import (
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenStorage(t *testing.T) {
+	storageFolder, err := paths.MkTempDir("", "db-TestOpenStorage")
+	require.NoError(t, err)
+	defer storageFolder.RemoveAll()
+
+	storage, err := OpenStorage("", storageFolder.Join("db.json").String())
+	require.NoError(t, err)
+	assert.IsType(t, &JSONStorage{}, storage)
+
+	storage, err = OpenStorage(BoltBackend, storageFolder.Join("db.bolt").String())
+	require.NoError(t, err)
+	assert.IsType(t, &BoltStorage{}, storage)
+	require.NoError(t, storage.Close())
+
+	_, err = OpenStorage("foo", storageFolder.Join("db.foo").String())
+	assert.Error(t, err)
+}
+
+func TestLoadExisting(t *testing.T) {
+	storageFolder, err := paths.MkTempDir("", "db-TestLoadExisting")
+	require.NoError(t, err)
+	defer storageFolder.RemoveAll()
+
+	for _, backend := range []string{JSONBackend, BoltBackend} {
+		storagePath := storageFolder.Join("db." + backend).String()
+		_, err := LoadExisting(backend, storagePath)
+		assert.Error(t, err, "Nonexistent %s database", backend)
+
+		storage, err := OpenStorage(backend, storagePath)
+		require.NoError(t, err)
+		require.NoError(t, testerDB().SaveToStorage(storage))
+		loadedDB, err := LoadExisting(backend, storagePath)
+		require.NoError(t, err, backend)
+		assert.Len(t, loadedDB.Libraries, 3, backend)
+	}
+}
+
+func TestStorage(t *testing.T) {
+	storageFolder, err := paths.MkTempDir("", "db-TestStorage")
+	require.NoError(t, err)
+	defer storageFolder.RemoveAll()
+
+	for _, backend := range []string{JSONBackend, BoltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			storagePath := storageFolder.Join("db." + backend).String()
+			storage, err := OpenStorage(backend, storagePath)
+			require.NoError(t, err)
+
+			// Migrate the test database to the storage.
+			require.NoError(t, testerDB().SaveToStorage(storage))
+			testDB, err := LoadFromStorage(storage)
+			require.NoError(t, err)
+			assert.Len(t, testDB.Libraries, 3)
+			assert.Len(t, testDB.Releases, 4)
+			release, err := testDB.FindRelease(&Release{LibraryName: "FooLib", Version: VersionFromString("1.0.0")})
+			require.NoError(t, err)
+			assert.Equal(t, "BazLib", release.Dependencies[0].Name)
+
+			// Changes, including removals, are saved.
+			require.NoError(t, testDB.RemoveLibrary("BazLib"))
+			require.NoError(t, testDB.AddLibrary(&Library{Name: "NewLib"}))
+			require.NoError(t, testDB.AddRelease(&Release{LibraryName: "NewLib", Version: VersionFromString("1.0.0")}, "https://github.com/Bar/NewLib.git"))
+			require.NoError(t, testDB.AddRejectedRelease(&RejectedRelease{LibraryName: "NewLib", Tag: "0.1.0", Reason: "lint failure"}))
+			require.NoError(t, testDB.Commit())
+			require.NoError(t, testDB.Close())
+
+			storage, err = OpenStorage(backend, storagePath)
+			require.NoError(t, err)
+			defer storage.Close()
+			savedDB, err := LoadFromStorage(storage)
+			require.NoError(t, err)
+			assert.Equal(t, CurrentSchemaVersion, savedDB.SchemaVersion)
+			assert.False(t, savedDB.HasLibrary("BazLib"))
+			assert.False(t, savedDB.HasReleaseByNameVersion("BazLib", "2.0.0"))
+			assert.True(t, savedDB.HasReleaseByNameVersion("NewLib", "1.0.0"))
+			assert.True(t, savedDB.HasReleaseByNameVersion("FooLib", "1.1.0"))
+			_, err = savedDB.FindRejectedRelease("NewLib", "0.1.0")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBoltStorageConcurrentAccess(t *testing.T) {
+	storageFolder, err := paths.MkTempDir("", "db-TestBoltStorageConcurrentAccess")
+	require.NoError(t, err)
+	defer storageFolder.RemoveAll()
+	storagePath := storageFolder.Join("db.bolt").String()
+
+	_, err = LoadFromStorage(NewBoltStorage(storagePath))
+	assert.Error(t, err, "Nonexistent store")
+
+	// A storage in use by a process must not prevent others from reading it.
+	storage := NewBoltStorage(storagePath)
+	defer storage.Close()
+	require.NoError(t, testerDB().SaveToStorage(storage))
+	readerDB, err := LoadFromStorage(NewBoltStorage(storagePath))
+	require.NoError(t, err)
+	assert.Len(t, readerDB.Libraries, 3)
+	require.NoError(t, testerDB().SaveToStorage(storage))
+}
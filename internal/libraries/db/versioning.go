@@ -23,7 +23,11 @@
 
 package db
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	semver "go.bug.st/relaxed-semver"
+)
 
 // Version is the type for library versions.
 type Version struct {
@@ -32,8 +36,18 @@ type Version struct {
 
 // Less returns whether the receiver version is lower than the argument.
 func (version *Version) Less(other Version) (bool, error) {
-	// TODO: apply semantic versioning
-	return version.version < other.version, nil
+	return version.Compare(other) < 0, nil
+}
+
+// Compare returns -1, 0 or 1 according to whether the receiver version is respectively lower than, equal to, or greater
+// than the argument.
+//
+// Versions are ordered by semantic versioning precedence, so "1.10.0" is greater than "1.9.0" and a pre-release is
+// lower than the associated release (e.g., "1.0.0-rc1" < "1.0.0"). "Relaxed" versions such as "1.2" are accepted and
+// treated as "1.2.0". Versions which can't be parsed at all are lower than any valid version and are ordered
+// lexicographically among themselves.
+func (version *Version) Compare(other Version) int {
+	return semver.ParseRelaxed(version.version).CompareTo(semver.ParseRelaxed(other.version))
 }
 
 // String returns the version in string form.
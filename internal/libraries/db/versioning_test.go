@@ -36,6 +36,45 @@ func TestLess(t *testing.T) {
 	res, err := v1.Less(v2)
 	require.NoError(t, err)
 	require.True(t, res)
+
+	less := func(lower string, higher string) {
+		lowerVersion := VersionFromString(lower)
+		higherVersion := VersionFromString(higher)
+		res, err := lowerVersion.Less(higherVersion)
+		require.NoError(t, err)
+		require.True(t, res, "%s < %s", lower, higher)
+		res, err = higherVersion.Less(lowerVersion)
+		require.NoError(t, err)
+		require.False(t, res, "!(%s < %s)", higher, lower)
+	}
+	less("1.9.0", "1.10.0")
+	less("1.2", "1.10")
+	less("1.0.0-rc1", "1.0.0")
+	less("1.0.0-beta", "1.0.0-rc1")
+	less("1.0.0", "1.0.1-rc1")
+	less("foo", "0.0.1")
+	less("bar", "foo")
+}
+
+func TestCompare(t *testing.T) {
+	testTables := []struct {
+		version  string
+		other    string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0", "1.0.0", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"1.9.0", "1.10.0", -1},
+		{"2.0.0-rc1", "2.0.0", -1},
+		{"not-a-version", "1.0.0", -1},
+		{"not-a-version", "not-a-version", 0},
+	}
+
+	for _, testTable := range testTables {
+		version := VersionFromString(testTable.version)
+		require.Equal(t, testTable.expected, version.Compare(VersionFromString(testTable.other)), "%s <=> %s", testTable.version, testTable.other)
+	}
 }
 
 func TestUnmarshalJSON(t *testing.T) {
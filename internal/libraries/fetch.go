@@ -0,0 +1,157 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package libraries
+
+import (
+	"compress/zlib"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/idxfile"
+	"github.com/go-git/go-git/v5/plumbing/format/objfile"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/filesystem/dotgit"
+)
+
+// FetchRetryPolicy defines how failed fetches of library repositories are retried.
+type FetchRetryPolicy struct {
+	Attempts int           // Maximum number of attempts when the failure is caused by a transient error.
+	Backoff  time.Duration // Delay before the first retry, doubled for each subsequent retry.
+}
+
+// CloneOrFetchWithRetry behaves like CloneOrFetch, but retries according to the policy in case of transient errors,
+// such as timeouts. In case of errors that indicate a corrupted clone, the clone is deleted and the repository is
+// cloned again.
+func CloneOrFetchWithRetry(repoMeta *Repo, folderName string, policy FetchRetryPolicy, logger *log.Logger) (*Repository, error) {
+	backoff := policy.Backoff
+	recloned := false
+	for attempt := 1; ; attempt++ {
+		repo, err := CloneOrFetch(repoMeta, folderName)
+		if err == nil {
+			return repo, nil
+		}
+		logger.Printf("Error fetching repository: %s", err)
+
+		switch {
+		case IsTransientFetchError(err):
+			if attempt >= policy.Attempts {
+				return nil, err
+			}
+			logger.Printf("Trying again in %s", backoff)
+			time.Sleep(backoff)
+			backoff *= 2
+		case IsUnavailableRepositoryError(err):
+			// A fresh clone would not help.
+			return nil, err
+		case IsCorruptCloneError(err) && !recloned:
+			logger.Printf("Removing clone and trying again")
+			os.RemoveAll(folderName)
+			recloned = true
+		default:
+			// The clone is left alone when the cause of the error is unknown, since cloning large repositories again is
+			// expensive.
+			return nil, err
+		}
+	}
+}
+
+// IsTransientFetchError returns whether the error from a fetch is of a type that is likely to not happen again on a
+// later attempt.
+func IsTransientFetchError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && (dnsErr.IsTemporary || dnsErr.IsTimeout) {
+		return true
+	}
+	for _, transientErr := range []error{syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ETIMEDOUT, syscall.ENETUNREACH, syscall.EHOSTUNREACH, io.ErrUnexpectedEOF} {
+		if errors.Is(err, transientErr) {
+			return true
+		}
+	}
+
+	// go-git does not wrap the errors from unexpected HTTP responses.
+	var unexpectedErr *plumbing.UnexpectedError
+	if errors.As(err, &unexpectedErr) {
+		var httpErr *githttp.Err
+		if errors.As(unexpectedErr.Err, &httpErr) {
+			return httpErr.StatusCode() >= http.StatusInternalServerError || httpErr.StatusCode() == http.StatusTooManyRequests
+		}
+	}
+
+	// Errors from the transport are not always wrapped either.
+	message := strings.ToLower(err.Error())
+	for _, transientMessage := range []string{"timeout", "timed out", "connection reset", "connection refused", "network is unreachable", "no route to host", "temporary failure", "unexpected eof"} {
+		if strings.Contains(message, transientMessage) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUnavailableRepositoryError returns whether the error from a fetch indicates that the remote repository no longer
+// exists or is not publicly accessible.
+func IsUnavailableRepositoryError(err error) bool {
+	for _, unavailableErr := range []error{transport.ErrRepositoryNotFound, transport.ErrAuthenticationRequired, transport.ErrAuthorizationFailed} {
+		if errors.Is(err, unavailableErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCorruptCloneError returns whether the error from a fetch indicates that the local clone of the repository is
+// damaged, so that it must be deleted and cloned again.
+func IsCorruptCloneError(err error) bool {
+	for _, corruptErr := range []error{
+		git.ErrRepositoryNotExists, git.ErrRepositoryIncomplete, git.ErrRemoteNotFound,
+		plumbing.ErrObjectNotFound, plumbing.ErrInvalidType,
+		packfile.ErrReferenceDeltaNotFound, packfile.ErrMalformedPackFile, packfile.ErrInvalidDelta, packfile.ErrDeltaCmd,
+		idxfile.ErrMalformedIdxFile, objfile.ErrHeader, objfile.ErrNegativeSize,
+		dotgit.ErrIdxNotFound, dotgit.ErrPackfileNotFound, dotgit.ErrConfigNotFound, dotgit.ErrPackedRefsBadFormat, dotgit.ErrEmptyRefFile,
+		zlib.ErrHeader, zlib.ErrChecksum,
+	} {
+		if errors.Is(err, corruptErr) {
+			return true
+		}
+	}
+
+	// The packfile errors are created with details, so they can't be matched by value.
+	var packfileErr *packfile.Error
+	return errors.As(err, &packfileErr)
+}
@@ -0,0 +1,133 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package libraries
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/filesystem/dotgit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransientFetchError(t *testing.T) {
+	httpErr := func(statusCode int) error {
+		return plumbing.NewUnexpectedError(&githttp.Err{
+			Response: &http.Response{
+				StatusCode: statusCode,
+				Request:    &http.Request{URL: &url.URL{Scheme: "https", Host: "github.com"}},
+			},
+		})
+	}
+
+	testTables := []struct {
+		testName    string
+		err         error
+		transient   bool
+		unavailable bool
+		corrupt     bool
+	}{
+		{"Connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true, false, false},
+		{"Timeout message", errors.New("dial tcp 140.82.121.4:443: i/o timeout"), true, false, false},
+		{"Server error", httpErr(http.StatusBadGateway), true, false, false},
+		{"Rate limit", httpErr(http.StatusTooManyRequests), true, false, false},
+		{"Client error", httpErr(http.StatusBadRequest), false, false, false},
+		{"Not found", fmt.Errorf("%w: ", transport.ErrRepositoryNotFound), false, true, false},
+		{"Authentication", fmt.Errorf("%w: ", transport.ErrAuthenticationRequired), false, true, false},
+		{"DNS failure", &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "server misbehaving", Name: "github.com", IsTemporary: true}}, true, false, false},
+		{"Unknown host", &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "github.com", IsNotFound: true}}, false, false, false},
+		{"Network unreachable", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)}, true, false, false},
+		{"Host unreachable", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.EHOSTUNREACH)}, true, false, false},
+		{"Missing object", plumbing.ErrObjectNotFound, false, false, true},
+		{"Not a repository", git.ErrRepositoryNotExists, false, false, true},
+		{"Malformed packfile", packfile.ErrInvalidObject.AddDetails("type %d", 0), false, false, true},
+		{"Missing packfile", fmt.Errorf("%w: ", dotgit.ErrPackfileNotFound), false, false, true},
+		{"Unknown error", errors.New("something went wrong"), false, false, false},
+	}
+
+	for _, testTable := range testTables {
+		assert.Equal(t, testTable.transient, IsTransientFetchError(testTable.err), testTable.testName)
+		assert.Equal(t, testTable.unavailable, IsUnavailableRepositoryError(testTable.err), testTable.testName)
+		assert.Equal(t, testTable.corrupt, IsCorruptCloneError(testTable.err), testTable.testName)
+	}
+}
+
+func TestCloneOrFetchWithRetry(t *testing.T) {
+	// Create a repository to serve as the remote.
+	remotePath, err := paths.MkTempDir("", "libraries-TestCloneOrFetchWithRetry-remote")
+	require.NoError(t, err)
+	defer remotePath.RemoveAll()
+	remote, err := git.PlainInit(remotePath.String(), false)
+	require.NoError(t, err)
+	require.NoError(t, remotePath.Join("library.properties").WriteFile([]byte("name=Foo")))
+	worktree, err := remote.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add(".")
+	require.NoError(t, err)
+	_, err = worktree.Commit("Test commit message", &git.CommitOptions{
+		Author: &object.Signature{Name: "Jane Developer", Email: "janedeveloper@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	clonesPath, err := paths.MkTempDir("", "libraries-TestCloneOrFetchWithRetry-clones")
+	require.NoError(t, err)
+	defer clonesPath.RemoveAll()
+	clonePath := clonesPath.Join("Foo")
+	logger := log.New(&bytes.Buffer{}, "", 0)
+	policy := FetchRetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+	// Simulate a corrupted clone.
+	require.NoError(t, clonePath.MkdirAll())
+	require.NoError(t, clonePath.Join("some-file").WriteFile([]byte{}))
+	repo, err := CloneOrFetchWithRetry(&Repo{URL: remotePath.String()}, clonePath.String(), policy, logger)
+	require.NoError(t, err, "Corrupted clone replaced")
+	assert.True(t, clonePath.Join("library.properties").Exist())
+	assert.Equal(t, clonePath.String(), repo.FolderPath)
+
+	_, err = CloneOrFetchWithRetry(&Repo{URL: clonesPath.Join("nonexistent").String()}, clonesPath.Join("Bar").String(), policy, logger)
+	assert.Error(t, err, "Nonexistent remote")
+
+	// The clone must be kept when the remote can't be fetched.
+	require.NoError(t, remotePath.RemoveAll())
+	_, err = CloneOrFetchWithRetry(&Repo{URL: remotePath.String()}, clonePath.String(), policy, logger)
+	assert.Error(t, err, "Unavailable remote")
+	assert.True(t, clonePath.Join("library.properties").Exist(), "Clone kept")
+}
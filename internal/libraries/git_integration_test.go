@@ -67,7 +67,7 @@ func TestUpdateLibraryJson(t *testing.T) {
 		err = gitutils.CheckoutTag(r.Repository, tag)
 		require.NoError(t, err)
 
-		library, err := libraries.GenerateLibraryFromRepo(r)
+		library, _, err := libraries.GenerateLibraryFromRepo(r)
 		require.NoError(t, err)
 		require.NotNil(t, library)
 
@@ -75,7 +75,8 @@ func TestUpdateLibraryJson(t *testing.T) {
 		archiveData, err := archive.New(r, library, &config)
 		require.NoError(t, err)
 
-		release := db.FromLibraryToRelease(library)
+		release, dependencyErrors := db.FromLibraryToRelease(library)
+		require.Empty(t, dependencyErrors)
 
 		err = archiveData.Create()
 		require.NoError(t, err)
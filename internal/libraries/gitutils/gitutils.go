@@ -24,12 +24,16 @@
 package gitutils
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 // resolveTag returns the commit hash associated with a tag.
@@ -41,6 +45,50 @@ func resolveTag(tag *plumbing.Reference, repository *git.Repository) (*plumbing.
 	return repository.ResolveRevision(plumbing.Revision(tag.Hash().String()))
 }
 
+// TagCommitHash returns the hash of the commit the tag refers to.
+func TagCommitHash(repository *git.Repository, tag *plumbing.Reference) (string, error) {
+	hash, err := resolveTag(tag, repository)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// TagProvenance is the origin of the content of a tag.
+type TagProvenance struct {
+	CommitHash string
+	CommitDate time.Time // Committer date of the tagged commit.
+	TagDate    time.Time // Tagger date. Zero for lightweight tags.
+}
+
+// GetTagProvenance returns the commit the tag refers to and the associated dates.
+func GetTagProvenance(repository *git.Repository, tag *plumbing.Reference) (*TagProvenance, error) {
+	hash, err := resolveTag(tag, repository)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repository.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	provenance := TagProvenance{
+		CommitHash: hash.String(),
+		CommitDate: commit.Committer.When.UTC(),
+	}
+
+	tagObject, err := repository.TagObject(tag.Hash())
+	switch {
+	case err == nil:
+		provenance.TagDate = tagObject.Tagger.When.UTC()
+	case errors.Is(err, plumbing.ErrObjectNotFound):
+		// Lightweight tag.
+	default:
+		return nil, err
+	}
+
+	return &provenance, nil
+}
+
 // SortedCommitTags returns the repository's commit object tags sorted by their chronological order in the current branch's history.
 // Tags for commits not in the branch's history are returned in lexicographical order relative to their adjacent tags.
 func SortedCommitTags(repository *git.Repository) ([]*plumbing.Reference, error) {
@@ -158,6 +206,39 @@ func SortedCommitTags(repository *git.Repository) ([]*plumbing.Reference, error)
 	return sortedTags, nil
 }
 
+// TagHashes returns a map of the repository's tag names to the hash of the object each tag refers to.
+func TagHashes(repository *git.Repository) (map[string]string, error) {
+	tags, err := repository.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	tagHashes := make(map[string]string)
+	err = tags.ForEach(func(tag *plumbing.Reference) error {
+		tagHashes[tag.Name().Short()] = tag.Hash().String()
+		return nil
+	})
+	return tagHashes, err
+}
+
+// RemoteTagHashes returns a map of the tag names of the repository at the given URL to the hash of the object each tag
+// refers to. Only the references are listed, so this is much cheaper than a fetch.
+func RemoteTagHashes(url string) (map[string]string, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{url}})
+	refs, err := remote.List(&git.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	tagHashes := make(map[string]string)
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tagHashes[ref.Name().Short()] = ref.Hash().String()
+		}
+	}
+	return tagHashes, nil
+}
+
 // CheckoutTag checks out the repository to the given tag.
 func CheckoutTag(repository *git.Repository, tag *plumbing.Reference) error {
 	repoTree, err := repository.Worktree()
@@ -165,6 +165,59 @@ func TestCheckoutTag(t *testing.T) {
 	}
 }
 
+func TestTagHashes(t *testing.T) {
+	// Create a folder for the test repository.
+	repositoryPath, err := paths.TempDir().MkTempDir("gitutils-TestTagHashes-repo")
+	require.NoError(t, err)
+
+	// Create test repository.
+	repository, err := git.PlainInit(repositoryPath.String(), false)
+	require.NoError(t, err)
+
+	annotatedTag := makeTag(t, repository, "1.0.0", makeCommit(t, repository, repositoryPath), true)
+	lightweightTag := makeTag(t, repository, "1.0.1", makeCommit(t, repository, repositoryPath), false)
+	expected := map[string]string{
+		"1.0.0": annotatedTag.Hash().String(),
+		"1.0.1": lightweightTag.Hash().String(),
+	}
+
+	tagHashes, err := TagHashes(repository)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tagHashes)
+
+	remoteTagHashes, err := RemoteTagHashes(repositoryPath.String())
+	require.NoError(t, err)
+	assert.Equal(t, expected, remoteTagHashes, "Remote tags match the local tags")
+}
+
+func TestGetTagProvenance(t *testing.T) {
+	// Create a folder for the test repository.
+	repositoryPath, err := paths.TempDir().MkTempDir("gitutils-TestGetTagProvenance-repo")
+	require.NoError(t, err)
+
+	// Create test repository.
+	repository, err := git.PlainInit(repositoryPath.String(), false)
+	require.NoError(t, err)
+
+	commitHash := makeCommit(t, repository, repositoryPath)
+	commit, err := repository.CommitObject(commitHash)
+	require.NoError(t, err)
+
+	provenance, err := GetTagProvenance(repository, makeTag(t, repository, "1.0.0", commitHash, true))
+	require.NoError(t, err)
+	assert.Equal(t, commitHash.String(), provenance.CommitHash)
+	assert.True(t, commit.Committer.When.Equal(provenance.CommitDate))
+	assert.False(t, provenance.TagDate.IsZero(), "Annotated tag has a date")
+
+	provenance, err = GetTagProvenance(repository, makeTag(t, repository, "1.0.1", commitHash, false))
+	require.NoError(t, err)
+	assert.Equal(t, commitHash.String(), provenance.CommitHash)
+	assert.True(t, provenance.TagDate.IsZero(), "Lightweight tag has no date")
+
+	_, err = GetTagProvenance(repository, makeTag(t, repository, "tree", getTreeHash(t, repository), false))
+	assert.Error(t, err)
+}
+
 // makeCommit creates a test commit in the given repository and returns its plumbing.Hash object.
 func makeCommit(t *testing.T, repository *git.Repository, repositoryPath *paths.Path) plumbing.Hash {
 	_, hash := commitFile(t, repository, repositoryPath)
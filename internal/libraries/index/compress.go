@@ -0,0 +1,84 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package index
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os/exec"
+)
+
+// Compression formats of the index variants. The format is used as the extension appended to the index path.
+const (
+	GzipFormat  = "gz"
+	Bzip2Format = "bz2"
+)
+
+// CheckCompression returns an error if data can't be compressed in the given format. The bz2 format requires the
+// bzip2 tool to be available in the PATH, since the Go standard library only provides a bzip2 decompressor.
+func CheckCompression(format string) error {
+	switch format {
+	case GzipFormat:
+		return nil
+	case Bzip2Format:
+		if _, err := exec.LookPath("bzip2"); err != nil {
+			return fmt.Errorf("bzip2 tool required by %s compression format: %w", format, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression format %s", format)
+	}
+}
+
+// Compress returns the data compressed in the given format. See CheckCompression for the requirements of the formats.
+func Compress(data []byte, format string) ([]byte, error) {
+	switch format {
+	case GzipFormat:
+		var buffer bytes.Buffer
+		writer, err := gzip.NewWriterLevel(&buffer, gzip.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	case Bzip2Format:
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command("bzip2", "--compress", "--stdout", "-9")
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("while running bzip2: %w: %s", err, stderr.String())
+		}
+		return stdout.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression format %s", format)
+	}
+}
@@ -0,0 +1,381 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package index handles the publication of the Library Manager index file.
+package index
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PreviousSuffix is appended to the index path to get the path of the rollback copy of the previously published index.
+const PreviousSuffix = ".previous"
+
+// library is the subset of the data of an index entry that is validated.
+type library struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	URL             string `json:"url"`
+	ArchiveFileName string `json:"archiveFileName"`
+	Size            int64  `json:"size"`
+	Checksum        string `json:"checksum"`
+}
+
+type index struct {
+	Libraries *[]library `json:"libraries"`
+}
+
+// Validate checks whether the data is a valid library index and returns the number of releases it contains.
+func Validate(data []byte) (int, error) {
+	var parsed index
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return 0, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if parsed.Libraries == nil {
+		return 0, errors.New("missing libraries array")
+	}
+
+	releases := make(map[string]bool)
+	for i, release := range *parsed.Libraries {
+		id := release.Name + "@" + release.Version
+		switch {
+		case release.Name == "":
+			return 0, fmt.Errorf("release #%d has no name", i)
+		case release.Version == "":
+			return 0, fmt.Errorf("release %s has no version", id)
+		case release.URL == "":
+			return 0, fmt.Errorf("release %s has no URL", id)
+		case release.ArchiveFileName == "":
+			return 0, fmt.Errorf("release %s has no archive file name", id)
+		case release.Size <= 0:
+			return 0, fmt.Errorf("release %s has invalid size %d", id, release.Size)
+		case !strings.HasPrefix(release.Checksum, "SHA-256:"):
+			return 0, fmt.Errorf("release %s has invalid checksum %s", id, release.Checksum)
+		}
+		if releases[id] {
+			return 0, fmt.Errorf("duplicate release %s", id)
+		}
+		releases[id] = true
+	}
+
+	return len(*parsed.Libraries), nil
+}
+
+// Changes is the difference between two library indexes. The entries are identified by "name@version".
+type Changes struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+// Compare returns the entries of the current index which were added, removed or changed compared to the previous one.
+func Compare(previous []byte, current []byte) (*Changes, error) {
+	previousEntries, err := entriesByID(previous)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous library index: %w", err)
+	}
+	currentEntries, err := entriesByID(current)
+	if err != nil {
+		return nil, fmt.Errorf("invalid library index: %w", err)
+	}
+
+	changes := Changes{}
+	for id, entry := range currentEntries {
+		previousEntry, found := previousEntries[id]
+		switch {
+		case !found:
+			changes.Added = append(changes.Added, id)
+		case !reflect.DeepEqual(entry, previousEntry):
+			changes.Changed = append(changes.Changed, id)
+		}
+	}
+	for id := range previousEntries {
+		if _, found := currentEntries[id]; !found {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Changed)
+
+	return &changes, nil
+}
+
+// entriesByID returns the entries of the index data, mapped by "name@version".
+func entriesByID(data []byte) (map[string]map[string]interface{}, error) {
+	var parsed struct {
+		Libraries []map[string]interface{} `json:"libraries"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]map[string]interface{})
+	for _, entry := range parsed.Libraries {
+		entries[fmt.Sprintf("%v@%v", entry["name"], entry["version"])] = entry
+	}
+	return entries, nil
+}
+
+// WriteOptions configures the publication of the index.
+type WriteOptions struct {
+	// If greater than zero, the index is rejected when its release count is lower than the count of the previous index
+	// by more than this fraction (e.g., 0.1 allows a drop of up to 10%).
+	MaxReleaseDrop float64
+	// Compression formats of the variants written alongside the index, at the index path with the format appended as
+	// extension (e.g., "library_index.json.gz").
+	Compressions []string
+	// Write a manifest of the SHA-256 checksums of the index and its variants, at the index path with ChecksumsSuffix
+	// appended.
+	Checksums bool
+	// If not nil, used to write a detached signature of the index at the index path with SignatureSuffix appended.
+	Signer Signer
+}
+
+// ChecksumsSuffix is appended to the index path to get the path of the checksum manifest.
+const ChecksumsSuffix = ".sha256"
+
+// file is a file to be published.
+type file struct {
+	path string
+	data []byte
+}
+
+// Write validates the index data and publishes it at the given path, along with the compressed variants, signature
+// and checksum manifest configured by the options. The previous index file is kept as a rollback copy at the path with
+// PreviousSuffix appended.
+//
+// The set of files is published atomically: the files are written to a new folder in the folder at the path with
+// VersionsSuffix appended to its hidden name, then the symbolic link at the path with CurrentSuffix appended to its
+// hidden name is switched to that folder. The published paths are symbolic links to the files of the current folder,
+// so readers never see files of different sets. An error before the switch leaves the published set unchanged. The
+// folder of the previous set is kept for the readers that are still accessing it, while those of older sets are
+// removed. The file system must support symbolic links.
+func Write(path string, data []byte, options WriteOptions) error {
+	releasesCount, err := Validate(data)
+	if err != nil {
+		return fmt.Errorf("invalid library index: %w", err)
+	}
+
+	previousData, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if previousData != nil && options.MaxReleaseDrop > 0 {
+		previousReleasesCount, err := Validate(previousData)
+		// An invalid previous index should not prevent publishing a valid one.
+		if err == nil && float64(releasesCount) < float64(previousReleasesCount)*(1-options.MaxReleaseDrop) {
+			return fmt.Errorf("release count of the library index dropped from %d to %d", previousReleasesCount, releasesCount)
+		}
+	}
+
+	files := []*file{}
+	for _, format := range options.Compressions {
+		compressed, err := Compress(data, format)
+		if err != nil {
+			return fmt.Errorf("while compressing library index: %w", err)
+		}
+		files = append(files, &file{path: path + "." + format, data: compressed})
+	}
+	if options.Signer != nil {
+		signature, err := options.Signer.Sign(data)
+		if err != nil {
+			return fmt.Errorf("while signing library index: %w", err)
+		}
+		files = append(files, &file{path: path + SignatureSuffix, data: signature})
+	}
+	files = append(files, &file{path: path, data: data})
+	if options.Checksums {
+		files = append(files, &file{path: path + ChecksumsSuffix, data: checksumManifest(files)})
+	}
+
+	versionsFolder := hiddenPath(path, VersionsSuffix)
+	if err := os.MkdirAll(versionsFolder, 0755); err != nil {
+		return err
+	}
+	setFolder, err := os.MkdirTemp(versionsFolder, time.Now().UTC().Format("20060102T150405Z")+"-")
+	if err != nil {
+		return err
+	}
+	published := false
+	defer func() {
+		if !published {
+			os.RemoveAll(setFolder)
+		}
+	}()
+	if err := os.Chmod(setFolder, 0755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeFile(filepath.Join(setFolder, filepath.Base(file.path)), file.data); err != nil {
+			return err
+		}
+	}
+
+	if previousData != nil {
+		if err := WriteFileAtomic(path+PreviousSuffix, previousData); err != nil {
+			return fmt.Errorf("while keeping copy of previous library index: %w", err)
+		}
+	}
+
+	currentLink := hiddenPath(path, CurrentSuffix)
+	previousSetFolder, _ := os.Readlink(currentLink)
+	if err := replaceSymlink(filepath.Join(filepath.Base(versionsFolder), filepath.Base(setFolder)), currentLink); err != nil {
+		return err
+	}
+	published = true
+
+	// The links only need to be created by the first publication, or when the files of the set change.
+	for _, file := range files {
+		target := filepath.Join(filepath.Base(currentLink), filepath.Base(file.path))
+		if currentTarget, err := os.Readlink(file.path); err == nil && currentTarget == target {
+			continue
+		}
+		if err := replaceSymlink(target, file.path); err != nil {
+			return err
+		}
+	}
+
+	return removeOldSets(versionsFolder, setFolder, previousSetFolder)
+}
+
+// VersionsSuffix is appended to the hidden name of the index file to get the name of the folder containing the
+// published sets of index files.
+const VersionsSuffix = ".versions"
+
+// CurrentSuffix is appended to the hidden name of the index file to get the name of the symbolic link to the folder
+// of the current set of index files.
+const CurrentSuffix = ".current"
+
+// hiddenPath returns the path of the hidden file named after the file at the given path, with the suffix appended.
+func hiddenPath(path string, suffix string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+suffix)
+}
+
+// replaceSymlink atomically replaces the file at the path with a symbolic link to the target.
+func replaceSymlink(target string, path string) error {
+	tempPath := fmt.Sprintf("%s.tmp-%d", path, time.Now().UnixNano())
+	if err := os.Symlink(target, tempPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+// removeOldSets removes the folders of the sets of index files other than the current and previous ones.
+func removeOldSets(versionsFolder string, currentSetFolder string, previousSetFolder string) error {
+	entries, err := os.ReadDir(versionsFolder)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Name() == filepath.Base(currentSetFolder) || entry.Name() == filepath.Base(previousSetFolder) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(versionsFolder, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checksumManifest returns a manifest of the checksums of the files, in the format of the sha256sum tool.
+func checksumManifest(files []*file) []byte {
+	var manifest strings.Builder
+	for _, file := range files {
+		fmt.Fprintf(&manifest, "%x  %s\n", sha256.Sum256(file.data), filepath.Base(file.path))
+	}
+	return []byte(manifest.String())
+}
+
+// WriteFileAtomic writes the data to a temporary file in the same folder as the path and then renames it to the path,
+// so that readers never see a partially written file.
+func WriteFileAtomic(path string, data []byte) error {
+	tempPath, err := writeTempFile(path, data)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempPath) // It's OK if the file was already renamed.
+
+	return os.Rename(tempPath, path)
+}
+
+// writeFile writes the data to a new file at the path and syncs it to the disk.
+func writeFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeTempFile writes the data to a temporary file in the same folder as the path and returns the temporary file's
+// path.
+func writeTempFile(path string, data []byte) (string, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	tempPath := file.Name()
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tempPath)
+		return "", err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tempPath)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tempPath)
+		return "", err
+	}
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		os.Remove(tempPath)
+		return "", err
+	}
+
+	return tempPath, nil
+}
@@ -0,0 +1,235 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package index
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeIndex returns the data of an index with the given number of releases.
+func makeIndex(releasesCount int) []byte {
+	var releases []string
+	for i := 0; i < releasesCount; i++ {
+		releases = append(releases, fmt.Sprintf(`{"name": "FooLib", "version": "1.0.%d", "url": "http://www.example.com/FooLib-1.0.%d.zip", "archiveFileName": "FooLib-1.0.%d.zip", "size": 123, "checksum": "SHA-256:887f897cfb1818a53652aef39c2a4b8de3c69c805520b2953a562a787b422420"}`, i, i, i))
+	}
+	return []byte(`{"libraries": [` + strings.Join(releases, ",") + `]}`)
+}
+
+func TestValidate(t *testing.T) {
+	count, err := Validate(makeIndex(3))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = Validate([]byte(`{"libraries": []}`))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	testTables := []struct {
+		testName string
+		data     string
+	}{
+		{"Truncated", string(makeIndex(3))[:100]},
+		{"No libraries", `{}`},
+		{"No name", `{"libraries": [{"version": "1.0.0", "url": "http://www.example.com/FooLib-1.0.0.zip", "archiveFileName": "FooLib-1.0.0.zip", "size": 123, "checksum": "SHA-256:887f"}]}`},
+		{"No size", `{"libraries": [{"name": "FooLib", "version": "1.0.0", "url": "http://www.example.com/FooLib-1.0.0.zip", "archiveFileName": "FooLib-1.0.0.zip", "checksum": "SHA-256:887f"}]}`},
+		{"Invalid checksum", `{"libraries": [{"name": "FooLib", "version": "1.0.0", "url": "http://www.example.com/FooLib-1.0.0.zip", "archiveFileName": "FooLib-1.0.0.zip", "size": 123, "checksum": "887f"}]}`},
+		{"Duplicate", `{"libraries": [
+			{"name": "FooLib", "version": "1.0.0", "url": "http://www.example.com/FooLib-1.0.0.zip", "archiveFileName": "FooLib-1.0.0.zip", "size": 123, "checksum": "SHA-256:887f"},
+			{"name": "FooLib", "version": "1.0.0", "url": "http://www.example.com/FooLib-1.0.0.zip", "archiveFileName": "FooLib-1.0.0.zip", "size": 123, "checksum": "SHA-256:887f"}
+		]}`},
+	}
+	for _, testTable := range testTables {
+		_, err := Validate([]byte(testTable.data))
+		assert.Error(t, err, testTable.testName)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	indexFolder, err := paths.MkTempDir("", "index-TestWrite")
+	require.NoError(t, err)
+	defer indexFolder.RemoveAll()
+	indexPath := indexFolder.Join("library_index.json")
+	previousPath := indexFolder.Join("library_index.json" + PreviousSuffix)
+
+	require.NoError(t, Write(indexPath.String(), makeIndex(10), WriteOptions{MaxReleaseDrop: 0.1}))
+	assert.True(t, indexPath.Exist())
+	assert.False(t, previousPath.Exist(), "No previous index to keep")
+
+	require.NoError(t, Write(indexPath.String(), makeIndex(9), WriteOptions{MaxReleaseDrop: 0.1}), "Drop within threshold")
+	data, err := previousPath.ReadFile()
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(10), data, "Previous index kept")
+
+	assert.Error(t, Write(indexPath.String(), makeIndex(5), WriteOptions{MaxReleaseDrop: 0.1}), "Drop over threshold")
+	require.NoError(t, Write(indexPath.String(), makeIndex(5), WriteOptions{}), "Drop check disabled")
+
+	assert.Error(t, Write(indexPath.String(), []byte("{"), WriteOptions{}), "Invalid index")
+	data, err = indexPath.ReadFile()
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(5), data, "Published index unchanged by failed write")
+
+	files, err := indexFolder.ReadDir()
+	require.NoError(t, err)
+	assert.Len(t, files, 4, "No temporary files left behind")
+}
+
+func TestWriteSet(t *testing.T) {
+	indexFolder, err := paths.MkTempDir("", "index-TestWriteSet")
+	require.NoError(t, err)
+	defer indexFolder.RemoveAll()
+	indexPath := indexFolder.Join("library_index.json")
+	currentLink := indexFolder.Join(".library_index.json" + CurrentSuffix)
+	versionsFolder := indexFolder.Join(".library_index.json" + VersionsSuffix)
+	options := WriteOptions{Compressions: []string{GzipFormat}, Checksums: true}
+
+	// A previously published index which is not part of a set.
+	require.NoError(t, indexPath.WriteFile(makeIndex(1)))
+
+	require.NoError(t, Write(indexPath.String(), makeIndex(2), options))
+	firstSet, err := os.Readlink(currentLink.String())
+	require.NoError(t, err)
+	for _, name := range []string{"library_index.json", "library_index.json.gz", "library_index.json" + ChecksumsSuffix} {
+		target, err := os.Readlink(indexFolder.Join(name).String())
+		require.NoError(t, err, name)
+		assert.Equal(t, filepath.Join(currentLink.Base(), name), target, "Published files link to the current set")
+		assert.True(t, indexFolder.Join(firstSet, name).Exist(), name)
+	}
+	data, err := indexPath.ReadFile()
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(2), data)
+
+	// A reader that resolved the first set keeps seeing its consistent content.
+	firstSetIndexPath := indexFolder.Join(firstSet, "library_index.json")
+	require.NoError(t, Write(indexPath.String(), makeIndex(3), options))
+	secondSet, err := os.Readlink(currentLink.String())
+	require.NoError(t, err)
+	assert.NotEqual(t, firstSet, secondSet)
+	data, err = firstSetIndexPath.ReadFile()
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(2), data, "Previous set kept")
+	data, err = indexPath.ReadFile()
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(3), data)
+	manifest, err := indexFolder.Join("library_index.json" + ChecksumsSuffix).ReadFile()
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), fmt.Sprintf("%x  library_index.json\n", sha256.Sum256(makeIndex(3))), "Manifest of the same set")
+
+	require.NoError(t, Write(indexPath.String(), makeIndex(4), options))
+	sets, err := versionsFolder.ReadDir()
+	require.NoError(t, err)
+	assert.Len(t, sets, 2, "Older sets removed")
+	assert.False(t, firstSetIndexPath.Exist())
+
+	// A failed write leaves the published set unchanged.
+	options.Compressions = []string{"foo"}
+	assert.Error(t, Write(indexPath.String(), makeIndex(5), options))
+	data, err = indexPath.ReadFile()
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(4), data)
+	sets, err = versionsFolder.ReadDir()
+	require.NoError(t, err)
+	assert.Len(t, sets, 2, "No set left behind by failed write")
+}
+
+func TestWriteVariants(t *testing.T) {
+	indexFolder, err := paths.MkTempDir("", "index-TestWriteVariants")
+	require.NoError(t, err)
+	defer indexFolder.RemoveAll()
+	indexPath := indexFolder.Join("library_index.json")
+
+	assert.NoError(t, CheckCompression(GzipFormat))
+	assert.NoError(t, CheckCompression(Bzip2Format))
+	assert.Error(t, CheckCompression("foo"))
+
+	options := WriteOptions{Compressions: []string{"foo"}, Checksums: true}
+	assert.Error(t, Write(indexPath.String(), makeIndex(3), options), "Unsupported compression format")
+	files, err := indexFolder.ReadDir()
+	require.NoError(t, err)
+	assert.Empty(t, files, "Nothing written by failed write")
+
+	options.Compressions = []string{GzipFormat, Bzip2Format}
+	require.NoError(t, Write(indexPath.String(), makeIndex(3), options))
+
+	gzipData, err := indexFolder.Join("library_index.json.gz").ReadFile()
+	require.NoError(t, err)
+	gzipReader, err := gzip.NewReader(bytes.NewReader(gzipData))
+	require.NoError(t, err)
+	data, err := io.ReadAll(gzipReader)
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(3), data)
+
+	bzip2Data, err := indexFolder.Join("library_index.json.bz2").ReadFile()
+	require.NoError(t, err)
+	data, err = io.ReadAll(bzip2.NewReader(bytes.NewReader(bzip2Data)))
+	require.NoError(t, err)
+	assert.Equal(t, makeIndex(3), data)
+
+	manifest, err := indexFolder.Join("library_index.json" + ChecksumsSuffix).ReadFile()
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		fmt.Sprintf("%x  library_index.json.gz\n%x  library_index.json.bz2\n%x  library_index.json\n", sha256.Sum256(gzipData), sha256.Sum256(bzip2Data), sha256.Sum256(makeIndex(3))),
+		string(manifest),
+	)
+}
+
+func TestCompare(t *testing.T) {
+	previous := []byte(`{"libraries": [
+		{"name": "FooLib", "version": "1.0.0", "size": 123},
+		{"name": "FooLib", "version": "1.1.0", "size": 123},
+		{"name": "BarLib", "version": "1.0.0", "size": 123}
+	]}`)
+	current := []byte(`{"libraries": [
+		{"name": "BarLib", "version": "1.0.0", "size": 123},
+		{"name": "FooLib", "version": "1.1.0", "size": 456},
+		{"name": "FooLib", "version": "1.2.0", "size": 123},
+		{"name": "BazLib", "version": "1.0.0", "size": 123}
+	]}`)
+
+	changes, err := Compare(previous, current)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BazLib@1.0.0", "FooLib@1.2.0"}, changes.Added)
+	assert.Equal(t, []string{"FooLib@1.0.0"}, changes.Removed)
+	assert.Equal(t, []string{"FooLib@1.1.0"}, changes.Changed)
+
+	changes, err = Compare(current, current)
+	require.NoError(t, err)
+	assert.Equal(t, &Changes{}, changes)
+
+	_, err = Compare([]byte("foo"), current)
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package index
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// SignatureSuffix is appended to the index path to get the path of its detached signature.
+const SignatureSuffix = ".sig"
+
+// Signer produces detached signatures of the index data.
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+}
+
+// LoadSigner returns a Signer using the private key from the given file. The key is either an unencrypted OpenPGP
+// private key, binary or armored, which produces binary signatures as used for the Arduino package indexes, or an
+// Ed25519 key in PKCS #8 PEM format, which produces raw Ed25519 signatures.
+func LoadSigner(path string) (Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if block, _ := pem.Decode(data); block != nil && block.Type == "PRIVATE KEY" {
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key %s: %w", path, err)
+		}
+		ed25519Key, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("private key %s is not an Ed25519 key", path)
+		}
+		return ed25519Signer(ed25519Key), nil
+	}
+
+	keyRing, err := readOpenPGPKeyRing(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key %s: %w", path, err)
+	}
+	if len(keyRing) != 1 || keyRing[0].PrivateKey == nil {
+		return nil, fmt.Errorf("%s must contain exactly one OpenPGP private key", path)
+	}
+	if keyRing[0].PrivateKey.Encrypted {
+		return nil, fmt.Errorf("private key %s is encrypted", path)
+	}
+	return (*openPGPSigner)(keyRing[0]), nil
+}
+
+// VerifySignature checks that the signature of the index data was made with the private key matching the public key
+// from the given file. The public key is in one of the formats corresponding to those supported by LoadSigner.
+func VerifySignature(data []byte, signature []byte, publicKeyPath string) error {
+	publicKeyData, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return err
+	}
+
+	if block, _ := pem.Decode(publicKeyData); block != nil && block.Type == "PUBLIC KEY" {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid public key %s: %w", publicKeyPath, err)
+		}
+		ed25519Key, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("public key %s is not an Ed25519 key", publicKeyPath)
+		}
+		if !ed25519.Verify(ed25519Key, data, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	keyRing, err := readOpenPGPKeyRing(publicKeyData)
+	if err != nil {
+		return fmt.Errorf("invalid public key %s: %w", publicKeyPath, err)
+	}
+	if bytes.HasPrefix(signature, []byte("-----BEGIN PGP")) {
+		_, err = openpgp.CheckArmoredDetachedSignature(keyRing, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	} else {
+		_, err = openpgp.CheckDetachedSignature(keyRing, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	return nil
+}
+
+// readOpenPGPKeyRing parses the binary or armored OpenPGP keys.
+func readOpenPGPKeyRing(data []byte) (openpgp.EntityList, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN PGP")) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+type ed25519Signer ed25519.PrivateKey
+
+// Sign returns the Ed25519 signature of the data.
+func (signer ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(ed25519.PrivateKey(signer), data), nil
+}
+
+type openPGPSigner openpgp.Entity
+
+// Sign returns the binary OpenPGP detached signature of the data.
+func (signer *openPGPSigner) Sign(data []byte) ([]byte, error) {
+	var signature bytes.Buffer
+	if err := openpgp.DetachSign(&signature, (*openpgp.Entity)(signer), bytes.NewReader(data), nil); err != nil {
+		return nil, err
+	}
+	return signature.Bytes(), nil
+}
@@ -0,0 +1,133 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package index
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOpenPGPKeys writes a new OpenPGP key pair to files in the folder and returns their paths. The public key is
+// armored.
+func writeOpenPGPKeys(t *testing.T, folder string, name string) (string, string) {
+	entity, err := openpgp.NewEntity(name, "", "", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	require.NoError(t, err)
+
+	var privateKey bytes.Buffer
+	require.NoError(t, entity.SerializePrivate(&privateKey, nil))
+	privateKeyPath := filepath.Join(folder, name+".gpg")
+	require.NoError(t, os.WriteFile(privateKeyPath, privateKey.Bytes(), 0600))
+
+	var publicKey bytes.Buffer
+	armorWriter, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(armorWriter))
+	require.NoError(t, armorWriter.Close())
+	publicKeyPath := filepath.Join(folder, name+".asc")
+	require.NoError(t, os.WriteFile(publicKeyPath, publicKey.Bytes(), 0644))
+
+	return privateKeyPath, publicKeyPath
+}
+
+// writeEd25519Keys writes a new Ed25519 key pair to PEM files in the folder and returns their paths.
+func writeEd25519Keys(t *testing.T, folder string, name string) (string, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privateKeyData, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privateKeyPath := filepath.Join(folder, name+".pem")
+	require.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyData}), 0600))
+
+	publicKeyData, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicKeyPath := filepath.Join(folder, name+".pub.pem")
+	require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyData}), 0644))
+
+	return privateKeyPath, publicKeyPath
+}
+
+func TestSignature(t *testing.T) {
+	keysFolder := t.TempDir()
+	openPGPPrivateKey, openPGPPublicKey := writeOpenPGPKeys(t, keysFolder, "openpgp")
+	_, otherOpenPGPPublicKey := writeOpenPGPKeys(t, keysFolder, "other-openpgp")
+	ed25519PrivateKey, ed25519PublicKey := writeEd25519Keys(t, keysFolder, "ed25519")
+	_, otherEd25519PublicKey := writeEd25519Keys(t, keysFolder, "other-ed25519")
+
+	testTables := []struct {
+		keyType         string
+		privateKey      string
+		publicKey       string
+		otherPublicKeys []string
+	}{
+		{"OpenPGP", openPGPPrivateKey, openPGPPublicKey, []string{otherOpenPGPPublicKey, ed25519PublicKey}},
+		{"Ed25519", ed25519PrivateKey, ed25519PublicKey, []string{otherEd25519PublicKey, openPGPPublicKey}},
+	}
+	for _, testTable := range testTables {
+		signer, err := LoadSigner(testTable.privateKey)
+		require.NoError(t, err, testTable.keyType)
+		data := makeIndex(3)
+		signature, err := signer.Sign(data)
+		require.NoError(t, err, testTable.keyType)
+
+		assert.NoError(t, VerifySignature(data, signature, testTable.publicKey), testTable.keyType)
+		assert.Error(t, VerifySignature(makeIndex(2), signature, testTable.publicKey), "%s: modified data", testTable.keyType)
+		for _, otherPublicKey := range testTable.otherPublicKeys {
+			assert.Error(t, VerifySignature(data, signature, otherPublicKey), "%s: other key %s", testTable.keyType, otherPublicKey)
+		}
+	}
+
+	_, err := LoadSigner(openPGPPublicKey)
+	assert.Error(t, err, "Public key")
+	_, err = LoadSigner(filepath.Join(keysFolder, "nonexistent"))
+	assert.Error(t, err, "Nonexistent key")
+}
+
+func TestWriteSignature(t *testing.T) {
+	indexFolder := t.TempDir()
+	indexPath := filepath.Join(indexFolder, "library_index.json")
+	privateKey, publicKey := writeEd25519Keys(t, t.TempDir(), "ed25519")
+	signer, err := LoadSigner(privateKey)
+	require.NoError(t, err)
+
+	require.NoError(t, Write(indexPath, makeIndex(3), WriteOptions{Signer: signer, Checksums: true}))
+	signature, err := os.ReadFile(indexPath + SignatureSuffix)
+	require.NoError(t, err)
+	assert.NoError(t, VerifySignature(makeIndex(3), signature, publicKey))
+	manifest, err := os.ReadFile(indexPath + ChecksumsSuffix)
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "  library_index.json"+SignatureSuffix+"\n")
+}
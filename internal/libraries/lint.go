@@ -25,10 +25,12 @@ package libraries
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
 var empty struct{}
@@ -47,7 +49,25 @@ func official(metadata *Repo) bool {
 	return false
 }
 
-// RunArduinoLint runs Arduino Lint on the library and returns the report in the event of error or warnings.
+// ArduinoLintVersion returns the version information of Arduino Lint.
+func ArduinoLintVersion(arduinoLintPath string) (string, error) {
+	if arduinoLintPath == "" {
+		// Assume Arduino Lint is installed under PATH.
+		arduinoLintPath = "arduino-lint"
+	}
+
+	output, err := exec.Command(arduinoLintPath, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// arduinoLintFailureExitCode is the exit code of Arduino Lint when rules failed, but also when it could not run.
+const arduinoLintFailureExitCode = 1
+
+// RunArduinoLint runs Arduino Lint on the library and returns the report in the event of error or warnings. If the
+// library failed the rules, the error is a *FindingsError.
 func RunArduinoLint(arduinoLintPath string, folder string, metadata *Repo) ([]byte, error) {
 	if arduinoLintPath == "" {
 		// Assume Arduino Lint is installed under PATH.
@@ -78,7 +98,11 @@ func RunArduinoLint(arduinoLintPath string, folder string, metadata *Repo) ([]by
 
 	textReport, lintErr := cmd.CombinedOutput()
 	if lintErr != nil {
-		return textReport, lintErr
+		var exitErr *exec.ExitError
+		if errors.As(lintErr, &exitErr) && exitErr.ExitCode() == arduinoLintFailureExitCode && lintErrorsReported(JSONReportPath) {
+			return textReport, &FindingsError{err: exitErr}
+		}
+		return textReport, fmt.Errorf("error running Arduino Lint: %w", lintErr)
 	}
 
 	// Read report.
@@ -103,3 +127,20 @@ func RunArduinoLint(arduinoLintPath string, folder string, metadata *Repo) ([]by
 	// No warnings.
 	return nil, nil
 }
+
+// lintErrorsReported returns whether the Arduino Lint report file exists and reports rule failures of error level.
+func lintErrorsReported(JSONReportPath string) bool {
+	rawJSONReport, err := os.ReadFile(JSONReportPath)
+	if err != nil {
+		return false
+	}
+	var JSONReport struct {
+		Summary struct {
+			ErrorCount int `json:"errorCount"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(rawJSONReport, &JSONReport); err != nil {
+		return false
+	}
+	return JSONReport.Summary.ErrorCount > 0
+}
@@ -76,7 +76,9 @@ func TestRunArduinoLint(t *testing.T) {
 			"Arduino_TestErr",
 			true,
 			"LS006",
-			assert.Error,
+			func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.True(t, IsFindingsError(err), msgAndArgs...)
+			},
 		},
 		{
 			"warning",
@@ -106,3 +108,9 @@ func TestRunArduinoLint(t *testing.T) {
 		testTable.errorAssertion(t, err, testTable.testName)
 	}
 }
+
+func TestRunArduinoLintMissing(t *testing.T) {
+	_, err := RunArduinoLint(filepath.Join(testDataPath, "nonexistent", "arduino-lint"), filepath.Join(testDataPath, "libraries", "Arduino_TestPass"), &Repo{})
+	assert.Error(t, err)
+	assert.False(t, IsFindingsError(err), "Failure to run the tool is not a finding")
+}
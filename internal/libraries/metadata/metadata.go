@@ -52,10 +52,54 @@ type LibraryMetadata struct {
 	Types         []string
 	Includes      string
 	Depends       string
+	Precompiled   string // One of the Precompiled* values. Empty if the library doesn't use precompiled binaries.
+	LDFlags       string
+	DotALinkage   bool
+	// Fields not defined by the library.properties specification, mapped by key. Nil if there are none.
+	ExtraFields map[string]string
+
+	dotALinkage string // Value of the dot_a_linkage field, which is not preserved by DotALinkage.
+}
+
+// Values of the precompiled field of libraries using precompiled binaries.
+const (
+	PrecompiledTrue = "true" // The precompiled binaries are used if available for the board, else the source is compiled.
+	PrecompiledFull = "full" // Only the precompiled binaries are used.
+)
+
+// specificationFields are the fields defined by the library.properties specification.
+var specificationFields = map[string]bool{
+	"name":          true,
+	"version":       true,
+	"author":        true,
+	"maintainer":    true,
+	"sentence":      true,
+	"paragraph":     true,
+	"license":       true,
+	"url":           true,
+	"architectures": true,
+	"category":      true,
+	"includes":      true,
+	"depends":       true,
+	"precompiled":   true,
+	"ldflags":       true,
+	"dot_a_linkage": true,
 }
 
 // Parse makes a LibraryMetadata by parsing a library.properties file contained in a byte array
 func Parse(propertiesData []byte) (*LibraryMetadata, error) {
+	library, err := parse(propertiesData)
+	if err != nil {
+		return nil, err
+	}
+
+	library.normalize()
+
+	return library, nil
+}
+
+// parse makes a LibraryMetadata from the library.properties data without normalizing it.
+func parse(propertiesData []byte) (*LibraryMetadata, error) {
 	// Create an io.Reader from []bytes
 	reader := bytes.NewReader(propertiesData)
 	// Use go-ini to decode contents
@@ -83,9 +127,21 @@ func Parse(propertiesData []byte) (*LibraryMetadata, error) {
 		Category:      get("category"),
 		Includes:      get("includes"),
 		Depends:       get("depends"),
+		Precompiled:   get("precompiled"),
+		LDFlags:       get("ldflags"),
+		DotALinkage:   get("dot_a_linkage") == "true",
+		dotALinkage:   get("dot_a_linkage"),
 	}
 
-	library.normalize()
+	for key, value := range properties[""] {
+		if specificationFields[key] {
+			continue
+		}
+		if library.ExtraFields == nil {
+			library.ExtraFields = make(map[string]string)
+		}
+		library.ExtraFields[key] = value
+	}
 
 	return library, nil
 }
@@ -94,6 +150,7 @@ func Parse(propertiesData []byte) (*LibraryMetadata, error) {
 func (library *LibraryMetadata) normalize() {
 	library.Version = normalizeVersion(library.Version)
 	library.Category = normalizeCategory(library.Category)
+	library.Precompiled = normalizePrecompiled(library.Precompiled)
 }
 
 // normalizeVersion converts "relaxed semver" to semver-compliant versions.
@@ -108,23 +165,35 @@ func normalizeVersion(version string) string {
 	return versionObject.String()
 }
 
+// validCategories are the allowed category values.
+var validCategories = map[string]bool{
+	"Display":             true,
+	"Communication":       true,
+	"Signal Input/Output": true,
+	"Sensors":             true,
+	"Device Control":      true,
+	"Timing":              true,
+	"Data Storage":        true,
+	"Data Processing":     true,
+	"Other":               true,
+	"Uncategorized":       true,
+}
+
 // normalizeCategory restricts category values to the allowed list.
 func normalizeCategory(category string) string {
-	var ValidCategories = map[string]bool{
-		"Display":             true,
-		"Communication":       true,
-		"Signal Input/Output": true,
-		"Sensors":             true,
-		"Device Control":      true,
-		"Timing":              true,
-		"Data Storage":        true,
-		"Data Processing":     true,
-		"Other":               true,
-		"Uncategorized":       true,
-	}
-	if !ValidCategories[category] {
+	if !validCategories[category] {
 		return "Uncategorized"
 	}
 
 	return category
 }
+
+// normalizePrecompiled restricts precompiled values to the Precompiled* values. Any other value means that the
+// library is compiled from source.
+func normalizePrecompiled(precompiled string) string {
+	if precompiled != PrecompiledTrue && precompiled != PrecompiledFull {
+		return ""
+	}
+
+	return precompiled
+}
@@ -171,6 +171,74 @@ depends=ArduinoHttpClient
 			},
 			errorAssertion: assert.NoError,
 		},
+		{
+			testName: "Precompiled",
+			propertiesData: []byte(`
+name=WebServer
+version=1.0.0
+author=Cristian Maglie <c.maglie@example.com>
+maintainer=Cristian Maglie <c.maglie@example.com>
+sentence=A library that makes coding a Webserver a breeze.
+paragraph=Supports HTTP1.1 and you can do GET and POST.
+category=Communication
+url=http://example.com/
+architectures=samd
+precompiled=full
+ldflags=-lm
+dot_a_linkage=true
+empty=
+foo=bar
+			`),
+			libraryMetadataAssertion: &LibraryMetadata{
+				Name:          "WebServer",
+				Version:       "1.0.0",
+				Author:        "Cristian Maglie <c.maglie@example.com>",
+				Maintainer:    "Cristian Maglie <c.maglie@example.com>",
+				Sentence:      "A library that makes coding a Webserver a breeze.",
+				Paragraph:     "Supports HTTP1.1 and you can do GET and POST.",
+				URL:           "http://example.com/",
+				Architectures: "samd",
+				Category:      "Communication",
+				Precompiled:   "full",
+				LDFlags:       "-lm",
+				DotALinkage:   true,
+				ExtraFields:   map[string]string{"empty": "", "foo": "bar"},
+				dotALinkage:   "true",
+			},
+			errorAssertion: assert.NoError,
+		},
+		{
+			testName: "Not precompiled",
+			propertiesData: []byte(`
+name=WebServer
+version=1.0.0
+precompiled=false
+dot_a_linkage=false
+			`),
+			libraryMetadataAssertion: &LibraryMetadata{
+				Name:        "WebServer",
+				Version:     "1.0.0",
+				Category:    "Uncategorized",
+				dotALinkage: "false",
+			},
+			errorAssertion: assert.NoError,
+		},
+		{
+			testName: "Invalid precompiled and dot_a_linkage",
+			propertiesData: []byte(`
+name=WebServer
+version=1.0.0
+precompiled=yes
+dot_a_linkage=1
+			`),
+			libraryMetadataAssertion: &LibraryMetadata{
+				Name:        "WebServer",
+				Version:     "1.0.0",
+				Category:    "Uncategorized",
+				dotALinkage: "1",
+			},
+			errorAssertion: assert.NoError,
+		},
 	}
 
 	for _, testTable := range testTables {
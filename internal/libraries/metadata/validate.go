@@ -0,0 +1,203 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package metadata
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	semver "go.bug.st/relaxed-semver"
+)
+
+// Severity levels of the validation findings.
+const (
+	SeverityError   = "error"   // The release can't be added to the index.
+	SeverityWarning = "warning" // The metadata is altered or ignored by the engine, or may cause problems to users.
+)
+
+// Finding is a problem found by the validation of the metadata.
+type Finding struct {
+	Field    string `json:"field"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String returns the finding in human readable format.
+func (finding *Finding) String() string {
+	return fmt.Sprintf("%s: %s field: %s", strings.ToUpper(finding.Severity), finding.Field, finding.Message)
+}
+
+// Validate checks the library.properties data and returns the problems found. An error is returned if the data can't
+// be parsed at all.
+func Validate(propertiesData []byte) ([]*Finding, error) {
+	library, err := parse(propertiesData)
+	if err != nil {
+		return nil, err
+	}
+	return library.validate(), nil
+}
+
+// HasErrors returns whether any of the findings has error severity.
+func HasErrors(findings []*Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+var architecturePattern = regexp.MustCompile(`^(\*|[a-zA-Z0-9_.-]+)$`)
+
+// validate returns the problems of the metadata, which must not be normalized.
+func (library *LibraryMetadata) validate() []*Finding {
+	findings := []*Finding{}
+	add := func(field string, severity string, format string, a ...interface{}) {
+		findings = append(findings, &Finding{Field: field, Severity: severity, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if library.Name == "" {
+		add("name", SeverityError, "missing required field")
+	}
+	if library.Version == "" {
+		add("version", SeverityError, "missing required field")
+	} else if _, err := semver.Parse(library.Version); err != nil {
+		add("version", SeverityError, "invalid version %s: %s", library.Version, err)
+	} else if normalized := normalizeVersion(library.Version); normalized != library.Version {
+		add("version", SeverityWarning, "version %s is not semver compliant, indexed as %s", library.Version, normalized)
+	}
+
+	for field, value := range map[string]string{
+		"author":        library.Author,
+		"maintainer":    library.Maintainer,
+		"sentence":      library.Sentence,
+		"paragraph":     library.Paragraph,
+		"url":           library.URL,
+		"architectures": library.Architectures,
+	} {
+		if strings.TrimSpace(value) == "" {
+			add(field, SeverityWarning, "missing required field")
+		}
+	}
+
+	if library.Category == "" {
+		add("category", SeverityWarning, "missing required field, indexed as Uncategorized")
+	} else if !validCategories[library.Category] {
+		add("category", SeverityWarning, "invalid category %s, indexed as Uncategorized", library.Category)
+	}
+
+	for _, architecture := range splitList(library.Architectures) {
+		if !architecturePattern.MatchString(architecture) {
+			add("architectures", SeverityWarning, "invalid architecture %q", architecture)
+		}
+	}
+
+	for _, include := range splitList(library.Includes) {
+		if include == "" {
+			add("includes", SeverityWarning, "empty entry ignored")
+		} else if !isHeaderFileName(include) {
+			add("includes", SeverityWarning, "%s is not a header file name", include)
+		}
+	}
+
+	if strings.TrimSpace(library.Depends) != "" {
+		for _, dependency := range strings.Split(library.Depends, ",") {
+			if _, _, err := ParseDependency(dependency); err != nil {
+				add("depends", SeverityWarning, "%s, entry ignored", err)
+			}
+		}
+	}
+
+	switch library.dotALinkage {
+	case "", "true", "false":
+	default:
+		add("dot_a_linkage", SeverityError, "invalid value %s, must be true or false", library.dotALinkage)
+	}
+
+	switch library.Precompiled {
+	case "", "false", PrecompiledTrue, PrecompiledFull:
+	default:
+		add("precompiled", SeverityError, "invalid value %s, must be true, full or false", library.Precompiled)
+	}
+
+	// The findings are sorted by field in the order of the specification.
+	sortFindings(findings)
+	return findings
+}
+
+var dependencyPattern = regexp.MustCompile("^([^()]+?) *(?: \\((.*)\\))?$")
+
+// ParseDependency parses an entry of the depends field and returns the name and version constraint of the dependency.
+func ParseDependency(entry string) (string, string, error) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return "", "", fmt.Errorf("invalid dep: empty entry")
+	}
+	matches := dependencyPattern.FindStringSubmatch(entry)
+	if matches == nil {
+		return "", "", fmt.Errorf("invalid dep: %s", entry)
+	}
+	if _, err := semver.ParseConstraint(matches[2]); err != nil {
+		return "", "", fmt.Errorf("invalid version constraint of dep %s: %s", entry, err)
+	}
+	return matches[1], matches[2], nil
+}
+
+// splitList returns the trimmed entries of a comma separated list. An empty list has no entries.
+func splitList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	entries := strings.Split(value, ",")
+	for i := range entries {
+		entries[i] = strings.TrimSpace(entries[i])
+	}
+	return entries
+}
+
+func isHeaderFileName(name string) bool {
+	for _, extension := range []string{".h", ".hh", ".hpp"} {
+		if strings.HasSuffix(name, extension) && len(name) > len(extension) && !strings.ContainsAny(name, " /\\") {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldOrder is the order of the fields in the library.properties specification.
+var fieldOrder = []string{"name", "version", "author", "maintainer", "sentence", "paragraph", "category", "url", "architectures", "depends", "dot_a_linkage", "includes", "precompiled", "ldflags"}
+
+func sortFindings(findings []*Finding) {
+	position := func(field string) int {
+		for i, candidate := range fieldOrder {
+			if candidate == field {
+				return i
+			}
+		}
+		return len(fieldOrder)
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return position(findings[i].Field) < position(findings[j].Field) })
+}
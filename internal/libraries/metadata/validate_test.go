@@ -0,0 +1,116 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	compliant := `
+name=WebServer
+version=1.0.0
+author=Cristian Maglie <c.maglie@example.com>
+maintainer=Cristian Maglie <c.maglie@example.com>
+sentence=A library that makes coding a Webserver a breeze.
+paragraph=Supports HTTP1.1 and you can do GET and POST.
+category=Communication
+url=http://example.com/
+architectures=avr, samd
+includes=WebServer.h
+depends=ArduinoHttpClient (>=1.0.0)
+`
+
+	testTables := []struct {
+		testName       string
+		propertiesData string
+		findings       []*Finding
+	}{
+		{"Compliant", compliant, []*Finding{}},
+		{
+			"Missing fields",
+			"name=WebServer\n",
+			[]*Finding{
+				{"version", SeverityError, "missing required field"},
+				{"author", SeverityWarning, "missing required field"},
+				{"maintainer", SeverityWarning, "missing required field"},
+				{"sentence", SeverityWarning, "missing required field"},
+				{"paragraph", SeverityWarning, "missing required field"},
+				{"category", SeverityWarning, "missing required field, indexed as Uncategorized"},
+				{"url", SeverityWarning, "missing required field"},
+				{"architectures", SeverityWarning, "missing required field"},
+			},
+		},
+		{
+			"Invalid values",
+			compliant + `
+version=1.0
+category=foo
+architectures=avr, sam d
+includes=WebServer.h,,WebServer.cpp
+depends=ArduinoHttpClient (foo), ArduinoJson
+`,
+			[]*Finding{
+				{"version", SeverityWarning, "version 1.0 is not semver compliant, indexed as 1.0.0"},
+				{"category", SeverityWarning, "invalid category foo, indexed as Uncategorized"},
+				{"architectures", SeverityWarning, `invalid architecture "sam d"`},
+				{"depends", SeverityWarning, "invalid version constraint of dep ArduinoHttpClient (foo): unexpected char at: foo, entry ignored"},
+				{"includes", SeverityWarning, "empty entry ignored"},
+				{"includes", SeverityWarning, "WebServer.cpp is not a header file name"},
+			},
+		},
+		{
+			"Invalid precompiled and dot_a_linkage",
+			compliant + `
+precompiled=yes
+ldflags=-lm
+dot_a_linkage=1
+`,
+			[]*Finding{
+				{"dot_a_linkage", SeverityError, "invalid value 1, must be true or false"},
+				{"precompiled", SeverityError, "invalid value yes, must be true, full or false"},
+			},
+		},
+	}
+	for _, testTable := range testTables {
+		findings, err := Validate([]byte(testTable.propertiesData))
+		require.NoError(t, err, testTable.testName)
+		assert.Equal(t, testTable.findings, findings, testTable.testName)
+	}
+
+	findings, err := Validate([]byte(compliant))
+	require.NoError(t, err)
+	assert.False(t, HasErrors(findings))
+
+	findings, err = Validate([]byte("name=WebServer\nversion=foo\n"))
+	require.NoError(t, err)
+	assert.True(t, HasErrors(findings))
+	assert.Equal(t, "ERROR: version field: invalid version foo: no major version found", findings[0].String())
+
+	_, err = Validate([]byte("broken"))
+	assert.Error(t, err)
+}
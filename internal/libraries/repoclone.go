@@ -82,26 +82,32 @@ func CloneOrFetch(repoMeta *Repo, folderName string) (*Repository, error) {
 		}
 	}
 
-	if err = repo.Repository.Fetch(&git.FetchOptions{Tags: git.AllTags}); err != nil {
+	// A repository without tags is already up to date once the local tags were deleted.
+	if err = repo.Repository.Fetch(&git.FetchOptions{Tags: git.AllTags}); err != nil && err != git.NoErrAlreadyUpToDate {
 		return nil, err
 	}
 
-	return &repo, err
+	return &repo, nil
 }
 
-// GenerateLibraryFromRepo parses a repository and returns the library metadata.
-func GenerateLibraryFromRepo(repo *Repository) (*metadata.LibraryMetadata, error) {
+// GenerateLibraryFromRepo parses a repository and returns the library metadata and the problems found by its
+// validation.
+func GenerateLibraryFromRepo(repo *Repository) (*metadata.LibraryMetadata, []*metadata.Finding, error) {
 	bytes, err := os.ReadFile(filepath.Join(repo.FolderPath, "library.properties"))
 	if err != nil {
-		return nil, fmt.Errorf("can't read library.properties: %s", err)
+		return nil, nil, fmt.Errorf("can't read library.properties: %w", err)
 	}
 
 	library, err := metadata.Parse(bytes)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	findings, err := metadata.Validate(bytes)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return library, nil
+	return library, findings, nil
 }
 
 // UpdateLibrary adds a release to the library database.
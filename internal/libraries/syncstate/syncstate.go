@@ -0,0 +1,211 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package syncstate persists the state of the library repositories at the time of their last successful sync, which
+// allows the sync process to skip repositories that have not changed.
+package syncstate
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// State is the sync state of all repositories.
+type State struct {
+	Repositories map[string]*Repository // Repository URL -> state.
+
+	stateFile string
+	mutex     sync.Mutex
+}
+
+// Repository is the state of a library repository at the time of its last successful sync.
+type Repository struct {
+	LibraryName string
+	Rules       string            // Identifies the rules applied to the releases at the last sync.
+	Tags        map[string]string // Tag name -> hash of the object the tag refers to.
+	LastSync    time.Time
+
+	FetchFailures  int // Number of consecutive failed fetches.
+	LastFetchError string
+}
+
+// New returns a new State object.
+func New(stateFile string) *State {
+	return &State{
+		Repositories: make(map[string]*Repository),
+		stateFile:    stateFile,
+	}
+}
+
+// Unchanged returns whether the repository's tags and the rules applied to its releases are the same as those recorded
+// at its last successful sync.
+func (state *State) Unchanged(repoURL string, libraryName string, rules string, tags map[string]string) bool {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	repository, found := state.Repositories[repoURL]
+	if !found {
+		return false
+	}
+	// A change of the registered library name affects which releases are accepted.
+	if repository.LibraryName != libraryName {
+		return false
+	}
+	// A change of the rules may affect whether the releases are accepted.
+	if repository.Rules != rules {
+		return false
+	}
+	return reflect.DeepEqual(repository.Tags, tags)
+}
+
+// Update records the tags of a successfully synced repository and the rules applied to its releases.
+func (state *State) Update(repoURL string, libraryName string, rules string, tags map[string]string) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.Repositories[repoURL] = &Repository{
+		LibraryName: libraryName,
+		Rules:       rules,
+		Tags:        tags,
+		LastSync:    time.Now().UTC(),
+	}
+}
+
+// RecordFetchFailure records a failed fetch of the repository and returns the number of consecutive failures.
+func (state *State) RecordFetchFailure(repoURL string, libraryName string, err error) int {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	repository, found := state.Repositories[repoURL]
+	if !found {
+		repository = &Repository{LibraryName: libraryName}
+		state.Repositories[repoURL] = repository
+	}
+	repository.FetchFailures++
+	repository.LastFetchError = err.Error()
+	return repository.FetchFailures
+}
+
+// RecordFetchSuccess resets the count of consecutive failed fetches of the repository.
+func (state *State) RecordFetchSuccess(repoURL string) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	if repository, found := state.Repositories[repoURL]; found {
+		repository.FetchFailures = 0
+		repository.LastFetchError = ""
+	}
+}
+
+// Forget removes the repository from the state, so that it will be fully processed by the next sync.
+func (state *State) Forget(repoURL string) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	delete(state.Repositories, repoURL)
+}
+
+// ForgetInFile removes the given repositories from the state stored in the given file. It's OK if the file does not
+// exist.
+func ForgetInFile(stateFile string, repoURLs ...string) error {
+	state, err := LoadFromFile(stateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, repoURL := range repoURLs {
+		state.Forget(repoURL)
+	}
+	return state.SaveToFile()
+}
+
+// LoadFromFile returns a State object loaded from the given filename.
+func LoadFromFile(filename string) (*State, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	state, err := Load(file)
+	if err != nil {
+		return nil, err
+	}
+	state.stateFile = filename
+	return state, nil
+}
+
+// Load returns a State object loaded from the given reader.
+func Load(r io.Reader) (*State, error) {
+	decoder := json.NewDecoder(r)
+	state := New("")
+	err := decoder.Decode(state)
+	if err != nil {
+		return nil, err
+	}
+	if state.Repositories == nil {
+		state.Repositories = make(map[string]*Repository)
+	}
+	return state, nil
+}
+
+// SaveToFile saves the state to a file.
+func (state *State) SaveToFile() error {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	file, err := os.Create(state.stateFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return state.save(file)
+}
+
+// Save writes the state via the given writer.
+func (state *State) Save(w io.Writer) error {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	return state.save(w)
+}
+
+func (state *State) save(w io.Writer) error {
+	buff, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buff)
+	return err
+}
+
+// Init loads a state from file and returns it.
+func Init(stateFile string) *State {
+	state, err := LoadFromFile(stateFile)
+	if err != nil {
+		log.Print(err)
+		log.Print("starting with an empty sync state")
+		return New(stateFile)
+	}
+	log.Printf("Loaded sync state of %v repositories", len(state.Repositories))
+	return state
+}
@@ -0,0 +1,107 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package syncstate
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnchanged(t *testing.T) {
+	state := New("")
+	tags := map[string]string{
+		"1.0.0": "6f2a1e8a0f3bd6f4e3f5c2c0a4f6f3b1a9e0d1c2",
+		"1.0.1": "0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d",
+	}
+
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags), "Unknown repository")
+
+	state.Update("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags)
+	assert.True(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags))
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Baz", "rules 1", tags), "Library name changed")
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 2", tags), "Rules changed")
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", map[string]string{"1.0.0": tags["1.0.0"]}), "Tag removed")
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", map[string]string{"1.0.0": tags["1.0.0"], "1.0.1": tags["1.0.0"]}), "Tag moved")
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", map[string]string{"1.0.0": tags["1.0.0"], "1.0.1": tags["1.0.1"], "1.0.2": tags["1.0.1"]}), "Tag added")
+
+	state.Forget("https://github.com/Foo/Bar.git")
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags), "Forgotten repository")
+}
+
+func TestSaveLoad(t *testing.T) {
+	stateFile, err := paths.WriteToTempFile([]byte{}, nil, "syncstate-TestSaveLoad")
+	require.NoError(t, err)
+	defer stateFile.Remove()
+
+	state := New(stateFile.String())
+	tags := map[string]string{"1.0.0": "6f2a1e8a0f3bd6f4e3f5c2c0a4f6f3b1a9e0d1c2"}
+	state.Update("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags)
+	require.NoError(t, state.SaveToFile())
+
+	loadedState, err := LoadFromFile(stateFile.String())
+	require.NoError(t, err)
+	assert.True(t, loadedState.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags))
+
+	emptyState, err := Load(bytes.NewBufferString("{}"))
+	require.NoError(t, err)
+	assert.False(t, emptyState.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags))
+}
+
+func TestForgetInFile(t *testing.T) {
+	stateFolder, err := paths.MkTempDir("", "syncstate-TestForgetInFile")
+	require.NoError(t, err)
+	defer stateFolder.RemoveAll()
+	stateFile := stateFolder.Join("state.json")
+
+	require.NoError(t, ForgetInFile(stateFile.String(), "https://github.com/Foo/Bar.git"), "Nonexistent file")
+
+	state := New(stateFile.String())
+	tags := map[string]string{"1.0.0": "6f2a1e8a0f3bd6f4e3f5c2c0a4f6f3b1a9e0d1c2"}
+	state.Update("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags)
+	state.Update("https://github.com/Foo/Baz.git", "Baz", "rules 1", tags)
+	require.NoError(t, state.SaveToFile())
+
+	require.NoError(t, ForgetInFile(stateFile.String(), "https://github.com/Foo/Bar.git"))
+	loadedState, err := LoadFromFile(stateFile.String())
+	require.NoError(t, err)
+	assert.False(t, loadedState.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", tags))
+	assert.True(t, loadedState.Unchanged("https://github.com/Foo/Baz.git", "Baz", "rules 1", tags))
+}
+
+func TestFetchFailures(t *testing.T) {
+	state := New("")
+	assert.Equal(t, 1, state.RecordFetchFailure("https://github.com/Foo/Bar.git", "Bar", errors.New("repository not found")))
+	assert.Equal(t, 2, state.RecordFetchFailure("https://github.com/Foo/Bar.git", "Bar", errors.New("repository not found")))
+	assert.Equal(t, "repository not found", state.Repositories["https://github.com/Foo/Bar.git"].LastFetchError)
+	assert.False(t, state.Unchanged("https://github.com/Foo/Bar.git", "Bar", "rules 1", map[string]string{}), "Never synced")
+
+	state.RecordFetchSuccess("https://github.com/Foo/Bar.git")
+	assert.Zero(t, state.Repositories["https://github.com/Foo/Bar.git"].FetchFailures)
+	assert.Equal(t, 1, state.RecordFetchFailure("https://github.com/Foo/Bar.git", "Bar", errors.New("timeout")))
+}
@@ -0,0 +1,183 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package lock implements the advisory lock preventing commands from concurrently modifying the engine's data.
+package lock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/arduino/libraries-repository-engine/internal/configuration"
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/spf13/pflag"
+)
+
+// Suffix is appended to the database file path to get the default path of the lock file.
+const Suffix = ".lock"
+
+// ErrLocked is returned when the lock is held by another process.
+var ErrLocked = errors.New("data is locked by another process")
+
+// pollInterval is the time between attempts to acquire a lock held by another process.
+var pollInterval = time.Second
+
+// Owner identifies the process holding the lock.
+type Owner struct {
+	PID       int
+	Host      string
+	Command   string
+	StartTime time.Time
+}
+
+func (owner *Owner) String() string {
+	return fmt.Sprintf("%s (PID %d on %s, started %s)", owner.Command, owner.PID, owner.Host, owner.StartTime.Format(time.RFC3339))
+}
+
+// Lock is an acquired lock.
+type Lock struct {
+	path string
+}
+
+// Path returns the path of the lock file protecting the data of the given configuration.
+func Path(config *configuration.Config) string {
+	if config.LockFile != "" {
+		return config.LockFile
+	}
+	return config.LibrariesDB + Suffix
+}
+
+// AcquireForConfig acquires the lock protecting the data of the given configuration on behalf of the named command,
+// waiting for as long as specified by the --lock-timeout flag.
+func AcquireForConfig(config *configuration.Config, flags *pflag.FlagSet, command string) (*Lock, error) {
+	timeout, err := flags.GetDuration("lock-timeout")
+	if err != nil {
+		return nil, err
+	}
+	return Acquire(Path(config), command, timeout)
+}
+
+// Acquire creates the lock file at the given path. If the lock is held by another process, it retries until the
+// timeout expires. A lock left behind by a process of the same host that is no longer running is taken over, except on
+// Windows, where it must be removed manually.
+func Acquire(path string, command string, timeout time.Duration) (*Lock, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	owner := &Owner{PID: os.Getpid(), Host: host, Command: command, StartTime: time.Now()}
+	ownerData, err := json.Marshal(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := create(path, ownerData)
+		if err == nil {
+			return &Lock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		currentOwner, err := readOwner(path)
+		if os.IsNotExist(err) {
+			continue // Released in the meantime.
+		}
+		if err == nil && currentOwner.Host == host && !processExists(currentOwner.PID) {
+			// Stale lock of a process that terminated without releasing it.
+			if err := removeStale(path, host); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				// The owner may not have finished writing the file, or it was interrupted while doing so.
+				return nil, fmt.Errorf("%w: unreadable lock file %s: %s", ErrLocked, path, err)
+			}
+			return nil, fmt.Errorf("%w: %s holds lock file %s", ErrLocked, currentOwner, path)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// create writes the lock file, failing if it already exists.
+func create(path string, ownerData []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(ownerData); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
+
+// readOwner returns the owner recorded in the lock file.
+func readOwner(path string) (*Owner, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return decodeOwner(file)
+}
+
+// decodeOwner returns the owner recorded in the lock file data.
+func decodeOwner(r io.Reader) (*Owner, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	owner := new(Owner)
+	if err := json.Unmarshal(data, owner); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+// Release removes the lock file.
+func (lock *Lock) Release() error {
+	return os.Remove(lock.path)
+}
+
+// Exit releases the lock and terminates the program with the given status code. It must be used instead of os.Exit
+// while the lock is held, since os.Exit doesn't run the deferred release.
+func (lock *Lock) Exit(code int) {
+	if err := lock.Release(); err != nil {
+		feedback.Errorf("While releasing lock: %s", err)
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,79 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lock
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	lockFolder, err := paths.MkTempDir("", "lock-TestAcquire")
+	require.NoError(t, err)
+	defer lockFolder.RemoveAll()
+	lockPath := lockFolder.Join("db.json" + Suffix)
+
+	lock, err := Acquire(lockPath.String(), "sync", 0)
+	require.NoError(t, err)
+	owner, err := readOwner(lockPath.String())
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), owner.PID)
+	assert.Equal(t, "sync", owner.Command)
+
+	_, err = Acquire(lockPath.String(), "remove", 0)
+	assert.True(t, errors.Is(err, ErrLocked))
+	assert.Contains(t, err.Error(), "sync (PID")
+
+	require.NoError(t, lock.Release())
+	assert.False(t, lockPath.Exist())
+	lock, err = Acquire(lockPath.String(), "remove", 0)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
+
+func TestAcquireWait(t *testing.T) {
+	defer func(original time.Duration) { pollInterval = original }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	lockFolder, err := paths.MkTempDir("", "lock-TestAcquireWait")
+	require.NoError(t, err)
+	defer lockFolder.RemoveAll()
+	lockPath := lockFolder.Join("db.json" + Suffix)
+
+	lock, err := Acquire(lockPath.String(), "sync", 0)
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		lock.Release()
+	}()
+	lock, err = Acquire(lockPath.String(), "modify", 10*time.Second)
+	require.NoError(t, err, "Lock acquired once released by the other owner")
+	require.NoError(t, lock.Release())
+}
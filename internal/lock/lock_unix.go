@@ -0,0 +1,76 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+//go:build unix
+
+package lock
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// removeStale removes the lock file if it's still owned by a process of this host that is no longer running. The check
+// and the removal are done while holding an exclusive flock of the file, and only if the file was not replaced in the
+// meantime, so that a concurrent takeover can't remove the lock acquired by another process after the stale one was
+// removed.
+func removeStale(path string, host string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close() // Also releases the flock.
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+
+	lockedInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	currentInfo, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !os.SameFile(lockedInfo, currentInfo) {
+		return nil // Already taken over.
+	}
+
+	owner, err := decodeOwner(file)
+	if err != nil || owner.Host != host || processExists(owner.PID) {
+		return nil // No longer stale, or not yet written by a new owner.
+	}
+	return os.Remove(path)
+}
+
+// processExists returns whether a process with the given PID is running on this host.
+func processExists(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	// EPERM means the process exists but belongs to another user.
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,55 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+//go:build windows
+
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// removeStale fails with ErrLocked. Windows doesn't allow removing the lock file while holding a lock on it, so the
+// check of the owner and the removal can't be done atomically, and a concurrent takeover could remove the lock acquired
+// by another process. The stale lock file must be removed manually instead.
+func removeStale(path string, host string) error {
+	owner, err := readOwner(path)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: lock file %s was left behind by %s, which is no longer running; remove it manually", ErrLocked, path, owner)
+}
+
+// processExists returns whether a process with the given PID is running on this host.
+func processExists(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		// Access is denied to the processes of other users.
+		return errors.Is(err, syscall.ERROR_ACCESS_DENIED)
+	}
+	process.Release()
+	return true
+}
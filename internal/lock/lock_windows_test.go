@@ -0,0 +1,60 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+//go:build windows
+
+package lock
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireStale(t *testing.T) {
+	lockFolder, err := paths.MkTempDir("", "lock-TestAcquireStale")
+	require.NoError(t, err)
+	defer lockFolder.RemoveAll()
+	lockPath := lockFolder.Join("db.json" + Suffix)
+	host, err := os.Hostname()
+	require.NoError(t, err)
+
+	// Get the PID of a terminated process.
+	process := exec.Command("cmd", "/c", "exit")
+	require.NoError(t, process.Run())
+	data, err := json.Marshal(&Owner{PID: process.Process.Pid, Host: host, Command: "sync", StartTime: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, lockPath.WriteFile(data))
+
+	_, err = Acquire(lockPath.String(), "remove", 0)
+	assert.True(t, errors.Is(err, ErrLocked))
+	assert.Contains(t, err.Error(), "remove it manually")
+	assert.True(t, lockPath.Exist(), "Stale lock not taken over")
+}
@@ -0,0 +1,79 @@
+// This file is part of libraries-repository-engine.
+//
+// Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package output implements the output formats shared by the CLI subcommands that print data from the libraries
+// database.
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/arduino/libraries-repository-engine/internal/feedback"
+	"github.com/spf13/pflag"
+)
+
+// Output formats.
+const (
+	TextFormat  = "text"
+	TableFormat = "table"
+	JSONFormat  = "json"
+	DOTFormat   = "dot"
+)
+
+// GetFormat returns the value of the format flag. The program exits with an error if the value is not one of the
+// given formats.
+func GetFormat(flags *pflag.FlagSet, formats ...string) string {
+	format, err := flags.GetString("format")
+	if err != nil {
+		panic(err)
+	}
+	for _, validFormat := range formats {
+		if format == validFormat {
+			return format
+		}
+	}
+	feedback.Errorf("Invalid output format %s, must be one of: %s", format, strings.Join(formats, ", "))
+	os.Exit(1)
+	return ""
+}
+
+// PrintJSON writes the value to stdout as indented JSON.
+func PrintJSON(value interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		panic(err)
+	}
+}
+
+// ContainsFold returns whether the values contain the value, ignoring case.
+func ContainsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -35,6 +35,27 @@ import pytest
 @pytest.fixture
 def configuration(working_dir):
     """Create a libraries-repository-engine configuration file and return an object containing its data and path."""
+    return create_configuration(working_dir=working_dir)
+
+
+@pytest.fixture
+def sync_state_configuration(working_dir):
+    """Create a libraries-repository-engine configuration file which persists the sync state, so that repositories
+    unchanged since the previous sync are skipped, and return an object containing its data and path.
+    """
+    return create_configuration(
+        working_dir=working_dir,
+        extra_data={"SyncStateFile": pathlib.Path(working_dir).joinpath("sync_state.json").as_posix()},
+    )
+
+
+def create_configuration(working_dir, extra_data=None):
+    """Create a libraries-repository-engine configuration file and return an object containing its data and path.
+
+    Keyword arguments:
+    working_dir -- path of the folder of the engine's data
+    extra_data -- dictionary of configuration data to add to the default data
+    """
     working_dir_path = pathlib.Path(working_dir)
 
     # This is based on the `Librariesv2` production job's config.
@@ -51,6 +72,8 @@ def configuration(working_dir):
         # Arduino Lint should be installed under PATH
         "ArduinoLintPath": "",
     }
+    if extra_data is not None:
+        data.update(extra_data)
 
     # Generate configuration file
     path = working_dir_path.joinpath("config.json")
@@ -0,0 +1,104 @@
+# Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published
+# by the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+#
+# You can be released from the requirements of the above licenses by purchasing
+# a commercial license. Buying such a license is mandatory if you want to
+# modify or otherwise use the software for commercial activities involving the
+# Arduino software without disclosing the source code of your own applications.
+# To purchase a commercial license, send an email to license@arduino.cc.
+#
+
+
+import json
+import pathlib
+
+test_data_path = pathlib.Path(__file__).resolve().parent.joinpath("testdata")
+
+
+def test_database_file_not_found(configuration, run_command):
+    """Test the commands' handling of a missing database file."""
+    for engine_command in [
+        ["deps", "show", "SpacebrewYun"],
+        ["deps", "cycles"],
+        ["deps", "broken"],
+        ["deps", "export"],
+    ]:
+        result = run_command(cmd=engine_command + ["--config-file", configuration.path])
+        assert not result.ok
+        assert "database file not found at {db_path}".format(db_path=configuration.data["LibrariesDB"]) in result.stderr
+
+
+def test_deps(configuration, run_command):
+    """Test the inspection of the dependency graph."""
+    result = run_command(
+        cmd=["sync", "--config-file", configuration.path, test_data_path.joinpath("test_deps", "repos.txt")]
+    )
+    assert result.ok
+
+    # show
+    result = run_command(cmd=["deps", "show", "--config-file", configuration.path, "SpacebrewYun"])
+    assert result.ok
+    assert "SpacebrewYun depends on:\n  Bridge in releases 1.0.2\n" in result.stdout
+    assert "Libraries depending on SpacebrewYun:\n  none\n" in result.stdout
+
+    result = run_command(cmd=["deps", "show", "--config-file", configuration.path, "--format", "json", "Bridge"])
+    assert result.ok
+    assert json.loads(result.stdout) == {
+        "name": "Bridge",
+        "dependencies": [],
+        "dependents": [{"from": "SpacebrewYun", "to": "Bridge", "releases": ["1.0.2"], "constraints": [""]}],
+    }
+
+    result = run_command(cmd=["deps", "show", "--config-file", configuration.path, "NonexistentLibrary"])
+    assert not result.ok
+    assert "Library of name NonexistentLibrary has no indexed releases and no dependents" in result.stderr
+
+    # cycles
+    result = run_command(cmd=["deps", "cycles", "--config-file", configuration.path])
+    assert result.ok
+    assert "0 dependency cycles" in result.stdout
+
+    result = run_command(cmd=["deps", "cycles", "--config-file", configuration.path, "--format", "json"])
+    assert result.ok
+    assert json.loads(result.stdout) == []
+
+    # broken
+    result = run_command(cmd=["deps", "broken", "--config-file", configuration.path])
+    assert result.ok
+    assert "SpacebrewYun in releases 1.0.2 depends on Bridge (missing)" in result.stdout
+    assert "1 dependencies on missing or retired libraries" in result.stdout
+
+    result = run_command(cmd=["deps", "broken", "--config-file", configuration.path, "--format", "json"])
+    assert result.ok
+    assert json.loads(result.stdout) == [
+        {"from": "SpacebrewYun", "to": "Bridge", "releases": ["1.0.2"], "constraints": [""]}
+    ]
+
+    # export
+    result = run_command(cmd=["deps", "export", "--config-file", configuration.path])
+    assert result.ok
+    assert result.stdout.startswith("digraph dependencies {")
+    assert '"SpacebrewYun" -> "Bridge"' in result.stdout
+
+    result = run_command(cmd=["deps", "export", "--config-file", configuration.path, "--format", "json"])
+    assert result.ok
+    assert json.loads(result.stdout) == {
+        "libraries": [{"name": "Bridge", "missing": True}, {"name": "SpacebrewYun"}],
+        "dependencies": [{"from": "SpacebrewYun", "to": "Bridge", "releases": ["1.0.2"], "constraints": [""]}],
+    }
+
+    result = run_command(cmd=["deps", "export", "--config-file", configuration.path, "--format", "text"])
+    assert not result.ok
+    assert "Invalid output format text, must be one of: dot, json" in result.stderr
@@ -0,0 +1,77 @@
+# Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published
+# by the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+#
+# You can be released from the requirements of the above licenses by purchasing
+# a commercial license. Buying such a license is mandatory if you want to
+# modify or otherwise use the software for commercial activities involving the
+# Arduino software without disclosing the source code of your own applications.
+# To purchase a commercial license, send an email to license@arduino.cc.
+#
+
+
+import json
+import pathlib
+
+test_data_path = pathlib.Path(__file__).resolve().parent.joinpath("testdata")
+
+
+def test_no_history(configuration, run_command):
+    """Test the command's handling of a library without history."""
+    result = run_command(cmd=["history", "--config-file", configuration.path, "SpacebrewYun"])
+    assert result.ok
+    assert "No history for library SpacebrewYun" in result.stdout
+
+
+def test_history(configuration, run_command):
+    """Test the output of the audit log of the changes made by the modify and remove commands."""
+    result = run_command(
+        cmd=["sync", "--config-file", configuration.path, test_data_path.joinpath("test_history", "repos.txt")]
+    )
+    assert result.ok
+
+    result = run_command(
+        cmd=["modify", "--config-file", configuration.path, "--types", "Arduino,Retired", "SpacebrewYun"]
+    )
+    assert result.ok
+    result = run_command(cmd=["remove", "--config-file", configuration.path, "SpacebrewYun@1.0.0"])
+    assert result.ok
+
+    result = run_command(cmd=["history", "--config-file", configuration.path, "SpacebrewYun"])
+    assert result.ok
+    assert " modify by " in result.stdout
+    assert 'release 1.0.2 Types: ["Contributed"] -> ["Arduino","Retired"]' in result.stdout
+    assert " remove by " in result.stdout
+    assert "release 1.0.0 removed" in result.stdout
+
+    result = run_command(cmd=["history", "--config-file", configuration.path, "--json", "SpacebrewYun"])
+    assert result.ok
+    entries = [json.loads(line) for line in result.stdout.splitlines()]
+    assert [entry["Command"] for entry in entries] == ["modify", "remove"]
+    for entry in entries:
+        assert entry["Library"] == "SpacebrewYun"
+    assert {
+        "Object": "release 1.0.2",
+        "Field": "Types",
+        "Before": ["Contributed"],
+        "After": ["Arduino", "Retired"],
+    } in entries[0]["Changes"]
+    assert entries[1]["Changes"][0]["Object"] == "release 1.0.0"
+    assert entries[1]["Changes"][0]["After"] is None
+    assert entries[1]["Files"][0]["Operation"] == "deleted"
+
+    # Other libraries are not affected
+    result = run_command(cmd=["history", "--config-file", configuration.path, "ArduinoCloudThing"])
+    assert result.ok
+    assert "No history for library ArduinoCloudThing" in result.stdout
@@ -0,0 +1,127 @@
+# Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published
+# by the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+#
+# You can be released from the requirements of the above licenses by purchasing
+# a commercial license. Buying such a license is mandatory if you want to
+# modify or otherwise use the software for commercial activities involving the
+# Arduino software without disclosing the source code of your own applications.
+# To purchase a commercial license, send an email to license@arduino.cc.
+#
+
+
+import json
+import pathlib
+
+test_data_path = pathlib.Path(__file__).resolve().parent.joinpath("testdata")
+
+
+def test_database_file_not_found(configuration, run_command):
+    """Test the commands' handling of a missing database file."""
+    for engine_command in [["list"], ["search", "SpacebrewYun"], ["show", "SpacebrewYun"]]:
+        result = run_command(cmd=engine_command + ["--config-file", configuration.path])
+        assert not result.ok
+        assert "database file not found at {db_path}".format(db_path=configuration.data["LibrariesDB"]) in result.stderr
+
+
+def test_list(configuration, run_command):
+    """Test the `list` command."""
+    sync(configuration=configuration, run_command=run_command)
+
+    result = run_command(cmd=["list", "--config-file", configuration.path])
+    assert result.ok
+    assert "NAME" in result.stdout
+    assert "SpacebrewYun" in result.stdout
+
+    result = run_command(cmd=["list", "--config-file", configuration.path, "--format", "json"])
+    assert result.ok
+    libraries = json.loads(result.stdout)
+    assert len(libraries) == 1
+    assert libraries[0]["name"] == "SpacebrewYun"
+    assert libraries[0]["latestVersion"] == "1.0.2"
+    assert libraries[0]["category"] == "Communication"
+    assert libraries[0]["types"] == ["Contributed"]
+    assert libraries[0]["architectures"] == ["avr"]
+    assert libraries[0]["repository"] == "https://github.com/arduino-libraries/SpacebrewYun.git"
+
+    # Filters
+    result = run_command(cmd=["list", "--config-file", configuration.path, "--format", "json", "--type", "Arduino"])
+    assert result.ok
+    assert json.loads(result.stdout) == []
+
+    result = run_command(
+        cmd=["list", "--config-file", configuration.path, "--format", "json", "--category", "Communication"]
+    )
+    assert result.ok
+    assert [library["name"] for library in json.loads(result.stdout)] == ["SpacebrewYun"]
+
+    result = run_command(cmd=["list", "--config-file", configuration.path, "--format", "foo"])
+    assert not result.ok
+
+
+def test_search(configuration, run_command):
+    """Test the `search` command."""
+    sync(configuration=configuration, run_command=run_command)
+
+    result = run_command(cmd=["search", "--config-file", configuration.path, "--format", "json", "spacebrew"])
+    assert result.ok
+    assert [library["name"] for library in json.loads(result.stdout)] == ["SpacebrewYun"]
+
+    result = run_command(cmd=["search", "--config-file", configuration.path, "--format", "json", "nonexistent"])
+    assert result.ok
+    assert json.loads(result.stdout) == []
+
+
+def test_show(configuration, run_command):
+    """Test the `show` command."""
+    sync(configuration=configuration, run_command=run_command)
+
+    result = run_command(cmd=["show", "--config-file", configuration.path, "SpacebrewYun"])
+    assert result.ok
+    assert "https://github.com/arduino-libraries/SpacebrewYun.git" in result.stdout
+    for version in ["1.0.0", "1.0.1", "1.0.2"]:
+        assert version in result.stdout
+
+    result = run_command(cmd=["show", "--config-file", configuration.path, "SpacebrewYun@1.0.2"])
+    assert result.ok
+    assert "Julio Terra <julioterra@gmail.com>" in result.stdout
+    assert "Bridge" in result.stdout
+
+    result = run_command(cmd=["show", "--config-file", configuration.path, "--format", "json", "SpacebrewYun@1.0.2"])
+    assert result.ok
+    release = json.loads(result.stdout)
+    assert release["Version"] == "1.0.2"
+    assert release["Dependencies"] == [{"Name": "Bridge", "Version": ""}]
+    assert pathlib.Path(release["ArchivePath"]).exists()
+
+    result = run_command(cmd=["show", "--config-file", configuration.path, "SpacebrewYun@9.9.9"])
+    assert not result.ok
+    assert "Library release SpacebrewYun@9.9.9 not found" in result.stderr
+
+    result = run_command(cmd=["show", "--config-file", configuration.path, "NonexistentLibrary"])
+    assert not result.ok
+    assert "Library of name NonexistentLibrary not found" in result.stderr
+
+
+def sync(configuration, run_command):
+    """Populate the database with the test data.
+
+    Keyword arguments:
+    configuration -- libraries-repository-engine configuration object
+    run_command -- the run_command fixture
+    """
+    result = run_command(
+        cmd=["sync", "--config-file", configuration.path, test_data_path.joinpath("test_query", "repos.txt")]
+    )
+    assert result.ok
@@ -78,6 +78,160 @@ def test_sync(configuration, run_command):
     check_index(configuration=configuration.data)
 
 
+def test_sync_dry_run(configuration, run_command):
+    """Test that a dry run reports the new releases without making any changes."""
+    engine_command = [
+        "sync",
+        "--config-file",
+        configuration.path,
+        "--dry-run",
+        test_data_path.joinpath("test_sync_flags", "repos.txt"),
+    ]
+    result = run_command(cmd=engine_command)
+    assert result.ok
+    assert "Dry run: no changes were made. Releases that would be added:" in result.stdout
+    for version in ["1.0.0", "1.0.1", "1.0.2"]:
+        assert f"SpacebrewYun@{version} (tag {version})" in result.stdout
+
+    assert not pathlib.Path(configuration.data["LibrariesDB"]).exists()
+    assert not pathlib.Path(configuration.data["LibrariesIndex"]).exists()
+    assert not pathlib.Path(configuration.data["LogsFolder"]).exists()
+    assert not pathlib.Path(configuration.data["LibrariesFolder"], "github.com").exists()
+
+
+def test_sync_report(configuration, run_command, working_dir):
+    """Test the JSON report of the sync outcome."""
+    report_path = pathlib.Path(working_dir, "report.json")
+    engine_command = [
+        "sync",
+        "--config-file",
+        configuration.path,
+        "--report",
+        report_path,
+        test_data_path.joinpath("test_sync_flags", "repos.txt"),
+    ]
+    result = run_command(cmd=engine_command)
+    assert result.ok
+
+    report = json.loads(report_path.read_text(encoding="utf-8"))
+    assert report["newReleasesCount"] == 3
+    assert report["librariesCount"] == 1
+    assert report["releasesCount"] == 3
+    assert report["indexedReleasesCount"] == 3
+    unresolved_dependencies = report["unresolvedDependencies"]["SpacebrewYun"]
+    assert len(unresolved_dependencies) == 1
+    assert unresolved_dependencies[0]["version"] == "1.0.2"
+    assert unresolved_dependencies[0]["dependency"] == {"Name": "Bridge", "Version": ""}
+    assert len(report["repositories"]) == 1
+    repository = report["repositories"][0]
+    assert repository["url"] == "https://github.com/arduino-libraries/SpacebrewYun.git"
+    assert repository["name"] == "SpacebrewYun"
+    assert repository["outcome"] == "synced"
+    assert {tag["tag"]: tag["outcome"] for tag in repository["tags"]} == {
+        "1.0.0": "indexed",
+        "1.0.1": "indexed",
+        "1.0.2": "indexed",
+    }
+
+    # Run the engine again
+    result = run_command(cmd=engine_command)
+    assert result.ok
+
+    report = json.loads(report_path.read_text(encoding="utf-8"))
+    assert report["newReleasesCount"] == 0
+    assert report["releasesCount"] == 3
+    assert {tag["tag"]: tag["outcome"] for tag in report["repositories"][0]["tags"]} == {
+        "1.0.0": "already-loaded",
+        "1.0.1": "already-loaded",
+        "1.0.2": "already-loaded",
+    }
+
+
+def test_sync_library(configuration, run_command):
+    """Test syncing only the libraries selected via the --library flag."""
+    engine_command = [
+        "sync",
+        "--config-file",
+        configuration.path,
+        "--library",
+        "SpacebrewYun",
+        test_data_path.joinpath("test_sync", "repos.txt"),
+    ]
+    result = run_command(cmd=engine_command)
+    assert result.ok
+
+    with pathlib.Path(configuration.data["LibrariesDB"]).open(mode="r", encoding="utf-8") as db_file:
+        db = json.load(fp=db_file)
+    assert [library["Name"] for library in db["Libraries"]] == ["SpacebrewYun"]
+    assert {release["LibraryName"] for release in db["Releases"]} == {"SpacebrewYun"}
+    assert not pathlib.Path(
+        configuration.data["LogsFolder"], "github.com", "arduino-libraries", "ArduinoCloudThing"
+    ).exists()
+
+    # A library not in the registry
+    engine_command = [
+        "sync",
+        "--config-file",
+        configuration.path,
+        "--library",
+        "NonexistentLibrary",
+        test_data_path.joinpath("test_sync", "repos.txt"),
+    ]
+    result = run_command(cmd=engine_command)
+    assert not result.ok
+    assert "NonexistentLibrary" in result.stderr
+
+
+def test_sync_state(sync_state_configuration, run_command, working_dir):
+    """Test that repositories unchanged since the previous sync are skipped unless the --recheck flag is used."""
+    configuration = sync_state_configuration
+    report_path = pathlib.Path(working_dir, "report.json")
+    engine_command = [
+        "sync",
+        "--config-file",
+        configuration.path,
+        "--report",
+        report_path,
+        test_data_path.joinpath("test_sync_state", "repos.txt"),
+    ]
+    result = run_command(cmd=engine_command)
+    assert result.ok
+    assert pathlib.Path(configuration.data["SyncStateFile"]).exists()
+    report = json.loads(report_path.read_text(encoding="utf-8"))
+    assert report["repositories"][0]["outcome"] == "synced"
+
+    # The tags of the repository did not change, so it is skipped
+    result = run_command(cmd=engine_command)
+    assert result.ok
+    assert "Tags unchanged since last sync, skipping" in result.stdout
+    report = json.loads(report_path.read_text(encoding="utf-8"))
+    assert report["repositories"][0]["outcome"] == "unchanged"
+    assert report["repositories"][0]["tags"] == []
+    assert report["releasesCount"] == 3
+    logs = pathlib.Path(
+        configuration.data["LogsFolder"], "github.com", "arduino-libraries", "SpacebrewYun", "index.html"
+    ).read_text(encoding="utf-8")
+    assert "Tags unchanged since last sync, skipping" in logs
+
+    # The --recheck flag makes the engine process the repository anyway
+    result = run_command(cmd=engine_command + ["--recheck"])
+    assert result.ok
+    assert "Tags unchanged since last sync, skipping" not in result.stdout
+    report = json.loads(report_path.read_text(encoding="utf-8"))
+    assert report["repositories"][0]["outcome"] == "synced"
+    assert {tag["tag"]: tag["outcome"] for tag in report["repositories"][0]["tags"]} == {
+        "1.0.0": "already-loaded",
+        "1.0.1": "already-loaded",
+        "1.0.2": "already-loaded",
+    }
+
+    # Libraries selected via the --library flag are always processed
+    result = run_command(cmd=engine_command + ["--library", "SpacebrewYun"])
+    assert result.ok
+    report = json.loads(report_path.read_text(encoding="utf-8"))
+    assert report["repositories"][0]["outcome"] == "synced"
+
+
 def check_libraries(configuration):
     """Run tests to determine whether the library release archives are as expected.
 
@@ -195,6 +349,15 @@ def check_db(configuration):
 
         assert golden_release is not None  # Matching golden release was found
 
+        # The provenance data depends on the time of the run and the engine build, so only its presence is checked
+        assert release.pop("Tag") != ""
+        assert re.fullmatch(pattern=r"[0-9a-f]{40}", string=release.pop("CommitHash"))
+        assert release.pop("CommitDate") != ""
+        release.pop("TagDate", None)  # Only present for annotated tags
+        assert release.pop("IndexTime") != ""
+        assert release.pop("EngineVersion") != ""
+        assert release.pop("ArduinoLintVersion") != ""
+
         # Small variation in size could result from compression algorithm changes, so we allow a tolerance
         assert "Size" in release
         assert math.isclose(release["Size"], golden_release["Size"], rel_tol=size_comparison_tolerance)
@@ -0,0 +1,77 @@
+# Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published
+# by the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+#
+# You can be released from the requirements of the above licenses by purchasing
+# a commercial license. Buying such a license is mandatory if you want to
+# modify or otherwise use the software for commercial activities involving the
+# Arduino software without disclosing the source code of your own applications.
+# To purchase a commercial license, send an email to license@arduino.cc.
+#
+
+
+import json
+import pathlib
+
+test_data_path = pathlib.Path(__file__).resolve().parent.joinpath("testdata")
+
+
+def test_database_file_not_found(configuration, run_command):
+    """Test the command's handling of a missing database file."""
+    result = run_command(cmd=["verify", "--config-file", configuration.path])
+    assert not result.ok
+    assert "database file not found at {db_path}".format(db_path=configuration.data["LibrariesDB"]) in result.stderr
+
+
+def test_verify(configuration, run_command):
+    """Test the verification of the release archives against the database."""
+    result = run_command(
+        cmd=["sync", "--config-file", configuration.path, test_data_path.joinpath("test_verify", "repos.txt")]
+    )
+    assert result.ok
+
+    result = run_command(cmd=["verify", "--config-file", configuration.path, "--fail"])
+    assert result.ok
+    assert "Verified 3 releases: 0 problems in 0 releases" in result.stdout
+
+    # Damage a release archive
+    with pathlib.Path(configuration.data["LibrariesDB"]).open(mode="r", encoding="utf-8") as db_file:
+        db = json.load(fp=db_file)
+    damaged_release = [release for release in db["Releases"] if release["Version"] == "1.0.1"][0]
+    damaged_archive_path = pathlib.Path(
+        configuration.data["LibrariesFolder"],
+        damaged_release["URL"].removeprefix(configuration.data["BaseDownloadUrl"]),
+    )
+    with damaged_archive_path.open(mode="ab") as archive_file:
+        archive_file.write(b"foo")
+
+    result = run_command(cmd=["verify", "--config-file", configuration.path])
+    assert result.ok
+    assert f"SpacebrewYun@1.0.1 ({damaged_archive_path})" in result.stdout
+
+    result = run_command(cmd=["verify", "--config-file", configuration.path, "--format", "json", "--fail"])
+    assert not result.ok
+    report = json.loads(result.stdout)
+    assert report["releases"] == 3
+    assert len(report["problems"]) == 1
+    assert report["problems"][0]["library"] == "SpacebrewYun"
+    assert report["problems"][0]["version"] == "1.0.1"
+    assert report["problems"][0]["archive"] == str(damaged_archive_path)
+    assert len(report["problems"][0]["problems"]) > 0
+
+    # Remove the release archive
+    damaged_archive_path.unlink()
+    result = run_command(cmd=["verify", "--config-file", configuration.path, "--fail"])
+    assert not result.ok
+    assert f"SpacebrewYun@1.0.1 ({damaged_archive_path})" in result.stdout
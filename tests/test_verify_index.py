@@ -0,0 +1,83 @@
+# Copyright 2026 ARDUINO SA (http://www.arduino.cc/)
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published
+# by the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+#
+# You can be released from the requirements of the above licenses by purchasing
+# a commercial license. Buying such a license is mandatory if you want to
+# modify or otherwise use the software for commercial activities involving the
+# Arduino software without disclosing the source code of your own applications.
+# To purchase a commercial license, send an email to license@arduino.cc.
+#
+
+
+import json
+import pathlib
+import shutil
+
+test_data_path = pathlib.Path(__file__).resolve().parent.joinpath("testdata")
+
+
+def test_verify_index(configuration, run_command, working_dir):
+    """Test the verification of the signature of the library index."""
+    # Make the engine sign the index
+    configuration.data["LibrariesIndexSigningKey"] = test_data_path.joinpath(
+        "test_verify_index", "private_key.pem"
+    ).as_posix()
+    with pathlib.Path(configuration.path).open(mode="w", encoding="utf-8") as configuration_file:
+        json.dump(obj=configuration.data, fp=configuration_file, indent=2)
+
+    result = run_command(
+        cmd=["sync", "--config-file", configuration.path, test_data_path.joinpath("test_verify_index", "repos.txt")]
+    )
+    assert result.ok
+    index_path = pathlib.Path(configuration.data["LibrariesIndex"])
+    signature_path = pathlib.Path(f"{index_path}.sig")
+    assert signature_path.exists()
+
+    public_key_path = test_data_path.joinpath("test_verify_index", "public_key.pem")
+    result = run_command(cmd=["verify-index", index_path, public_key_path])
+    assert result.ok
+    assert f"Signature of {index_path} is valid" in result.stdout
+
+    # Signature file at a custom path
+    custom_signature_path = pathlib.Path(working_dir, "signature")
+    shutil.copyfile(signature_path, custom_signature_path)
+    result = run_command(cmd=["verify-index", index_path, public_key_path, custom_signature_path])
+    assert result.ok
+
+    # Wrong public key
+    result = run_command(
+        cmd=["verify-index", index_path, test_data_path.joinpath("test_verify_index", "other_public_key.pem")]
+    )
+    assert not result.ok
+    assert f"Verification of {index_path} failed" in result.stderr
+
+    # Modified index
+    with index_path.open(mode="a", encoding="utf-8") as index_file:
+        index_file.write("\n")
+    result = run_command(cmd=["verify-index", index_path, public_key_path])
+    assert not result.ok
+    assert f"Verification of {index_path} failed" in result.stderr
+
+
+def test_signature_file_not_found(run_command, working_dir):
+    """Test the command's handling of a missing signature file."""
+    index_path = pathlib.Path(working_dir, "library_index.json")
+    index_path.write_text('{"libraries": []}', encoding="utf-8")
+
+    result = run_command(
+        cmd=["verify-index", index_path, test_data_path.joinpath("test_verify_index", "public_key.pem")]
+    )
+    assert not result.ok
+    assert "While reading signature" in result.stderr